@@ -18,6 +18,7 @@ export default defineConfig({
             { label: 'Introduction', link: '/' },
             { label: 'Installation', link: '/installation' },
             { label: 'Configuration', link: '/configuration' },
+            { label: 'Finding Codes', link: '/finding-codes' },
             // { label: 'Contributing', link: '/contributing' },
           ],
         },
@@ -10,19 +10,25 @@ var version = "dev"
 
 func main() {
 	plugin.Serve(&plugin.ServeOpts{
-		RuleSet: &tflint.BuiltinRuleSet{
-			Name:    "aws-meta",
-			Version: version,
-			Rules: []tflint.Rule{
-				rules.NewAwsMetaHardcodedRule(),
-				rules.NewAwsHardcodedIDsRule(),
-				rules.NewAwsIamRolePolicyHardcodedRegionRule(),
-				rules.NewAwsIamRolePolicyHardcodedPartitionRule(),
-				rules.NewAwsIamPolicyHardcodedRegionRule(),
-				rules.NewAwsIamPolicyHardcodedPartitionRule(),
-				rules.NewAwsProviderHardcodedRegionRule(),
-				rules.NewAwsServicePrincipalHardcodedRule(),
-				rules.NewAwsServicePrincipalDNSSuffixRule(),
+		RuleSet: &rules.RuleSet{
+			BuiltinRuleSet: tflint.BuiltinRuleSet{
+				Name:    "aws-meta",
+				Version: version,
+				Rules: []tflint.Rule{
+					rules.NewAwsMetaHardcodedRule(),
+					rules.NewAwsHardcodedIDsRule(),
+					rules.NewAwsIamRolePolicyHardcodedRegionRule(),
+					rules.NewAwsIamRolePolicyHardcodedPartitionRule(),
+					rules.NewAwsIamPolicyHardcodedRegionRule(),
+					rules.NewAwsIamPolicyHardcodedPartitionRule(),
+					rules.NewAwsProviderHardcodedRegionRule(),
+					rules.NewAwsServicePrincipalHardcodedRule(),
+					rules.NewAwsServicePrincipalDNSSuffixRule(),
+					// Run last, as they report the annotations and baseline entries
+					// the rules above didn't use
+					rules.NewAwsMetaAnnotationRule(),
+					rules.NewAwsMetaBaselineRule(),
+				},
 			},
 		},
 	})
@@ -0,0 +1,222 @@
+package rules
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// annotationPrefix starts a comment that allows hardcoded values, such as
+//
+//	# aws-meta:allow region reason="CloudFront requires us-east-1" until=2027-01-01
+const annotationPrefix = "aws-meta:allow"
+
+// timeNow returns the current time, which annotations expire against
+var timeNow = time.Now
+
+// annotation is an aws-meta:allow comment. It allows issues of its kinds on
+// the line of the comment and on the next line, like tflint-ignore.
+type annotation struct {
+	Kinds  []string
+	Reason string
+
+	// Until is the last day the annotation applies, or zero if it doesn't expire
+	Until time.Time
+
+	// Range is the range of the comment
+	Range hcl.Range
+
+	// Err is why the annotation is malformed, in which case it allows nothing
+	Err error
+
+	// used is set once the annotation matched an issue
+	used bool
+}
+
+// appliesTo reports whether the annotation covers an issue of the kind at rng
+func (a *annotation) appliesTo(kind string, rng hcl.Range) bool {
+	if a.Err != nil || rng.Filename != a.Range.Filename || !slices.Contains(a.Kinds, kind) {
+		return false
+	}
+	return rng.Start.Line == a.Range.Start.Line || rng.Start.Line == a.Range.Start.Line+1
+}
+
+// expired reports whether the last day of the annotation has passed
+func (a *annotation) expired(now time.Time) bool {
+	return !a.Until.IsZero() && !now.Before(a.Until.AddDate(0, 0, 1))
+}
+
+// parseAnnotations returns the aws-meta:allow annotations in the comments of
+// native syntax files, in file order
+func parseAnnotations(files map[string]*hcl.File) []*annotation {
+	filenames := make([]string, 0, len(files))
+	for filename := range files {
+		filenames = append(filenames, filename)
+	}
+	slices.Sort(filenames)
+
+	var annotations []*annotation
+	for _, filename := range filenames {
+		file := files[filename]
+		if _, ok := file.Body.(*hclsyntax.Body); !ok {
+			continue
+		}
+
+		tokens, _ := hclsyntax.LexConfig(file.Bytes, filename, hcl.InitialPos)
+		for _, token := range tokens {
+			if token.Type != hclsyntax.TokenComment {
+				continue
+			}
+			if a, ok := parseAnnotation(string(token.Bytes), token.Range); ok {
+				annotations = append(annotations, a)
+			}
+		}
+	}
+	return annotations
+}
+
+// parseAnnotation parses a comment, returning false if it isn't an annotation
+func parseAnnotation(comment string, rng hcl.Range) (*annotation, bool) {
+	trimmed := strings.TrimRight(comment, "\r\n")
+	text := trimmed
+	switch {
+	case strings.HasPrefix(text, "#"):
+		text = text[1:]
+	case strings.HasPrefix(text, "//"):
+		text = text[2:]
+	case strings.HasPrefix(text, "/*"):
+		text = strings.TrimSuffix(text[2:], "*/")
+	}
+	text = strings.TrimSpace(text)
+
+	rest, ok := strings.CutPrefix(text, annotationPrefix)
+	if !ok || (rest != "" && rest[0] != ' ' && rest[0] != '\t') {
+		return nil, false
+	}
+
+	a := &annotation{Range: subRange(rng, comment, 0, len(trimmed))}
+	a.Err = a.parseFields(rest)
+	return a, true
+}
+
+// parseFields parses the kinds and options that follow the prefix
+func (a *annotation) parseFields(text string) error {
+	fields, err := splitAnnotationFields(text)
+	if err != nil {
+		return err
+	}
+	if len(fields) == 0 {
+		return errors.New("missing finding kind")
+	}
+
+	for _, kind := range strings.Split(fields[0], ",") {
+		if !slices.Contains(findingKinds, kind) {
+			return fmt.Errorf("unknown finding kind %q, expected one of %s", kind, strings.Join(findingKinds, ", "))
+		}
+		a.Kinds = append(a.Kinds, kind)
+	}
+
+	for _, field := range fields[1:] {
+		key, value, _ := strings.Cut(field, "=")
+		switch key {
+		case "reason":
+			if strings.HasPrefix(value, `"`) {
+				unquoted, err := strconv.Unquote(value)
+				if err != nil {
+					return fmt.Errorf("invalid reason %s", value)
+				}
+				value = unquoted
+			}
+			a.Reason = value
+		case "until":
+			until, err := time.Parse(time.DateOnly, value)
+			if err != nil {
+				return fmt.Errorf("invalid until date %q, expected YYYY-MM-DD", value)
+			}
+			a.Until = until
+		default:
+			return fmt.Errorf("unknown option %q", key)
+		}
+	}
+
+	if a.Reason == "" {
+		return errors.New("missing reason")
+	}
+	return nil
+}
+
+// splitAnnotationFields splits text on spaces outside of double quotes
+func splitAnnotationFields(text string) ([]string, error) {
+	var fields []string
+	var field strings.Builder
+	quoted := false
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case c == '\\' && quoted && i+1 < len(text):
+			field.WriteByte(c)
+			i++
+			field.WriteByte(text[i])
+			continue
+		case c == '"':
+			quoted = !quoted
+		case (c == ' ' || c == '\t') && !quoted:
+			if field.Len() > 0 {
+				fields = append(fields, field.String())
+				field.Reset()
+			}
+			continue
+		}
+		field.WriteByte(c)
+	}
+	if quoted {
+		return nil, errors.New("unterminated quote")
+	}
+	if field.Len() > 0 {
+		fields = append(fields, field.String())
+	}
+	return fields, nil
+}
+
+// runnerAnnotations returns the annotations of the module. Within a check of
+// the ruleset they are shared by the rules, so their use is tracked.
+func runnerAnnotations(runner tflint.Runner) ([]*annotation, error) {
+	if r, ok := runner.(*checkRunner); ok {
+		return r.annotations()
+	}
+
+	files, err := runner.GetFiles()
+	if err != nil {
+		return nil, err
+	}
+	return parseAnnotations(files), nil
+}
+
+// allowIssue looks for an annotation allowing the match at rng. It returns
+// whether the match is allowed, and the message to report it with otherwise,
+// which notes an annotation that has expired.
+func allowIssue(runner tflint.Runner, match Match, rng hcl.Range) (string, bool, error) {
+	annotations, err := runnerAnnotations(runner)
+	if err != nil {
+		return "", false, err
+	}
+
+	for _, a := range annotations {
+		if !a.appliesTo(match.Kind, rng) {
+			continue
+		}
+		a.used = true
+		if a.expired(timeNow()) {
+			return fmt.Sprintf("%s (%s expired on %s)", match.Message, annotationPrefix, a.Until.Format(time.DateOnly)), false, nil
+		}
+		return "", true, nil
+	}
+	return match.Message, false, nil
+}
@@ -0,0 +1,212 @@
+package rules
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+func Test_ParseAnnotation(t *testing.T) {
+	tests := []struct {
+		Name       string
+		Comment    string
+		Annotation bool
+		Kinds      []string
+		Reason     string
+		Until      string
+		Err        string
+	}{
+		{
+			Name:       "kind, reason and expiry",
+			Comment:    "# aws-meta:allow region reason=\"CloudFront requires us-east-1\" until=2027-01-01\n",
+			Annotation: true,
+			Kinds:      []string{"region"},
+			Reason:     "CloudFront requires us-east-1",
+			Until:      "2027-01-01",
+		},
+		{
+			Name:       "several kinds in a slash comment",
+			Comment:    "// aws-meta:allow region,availability_zone reason=legacy\n",
+			Annotation: true,
+			Kinds:      []string{"region", "availability_zone"},
+			Reason:     "legacy",
+		},
+		{
+			Name:       "block comment",
+			Comment:    `/* aws-meta:allow partition reason="GovCloud only" */`,
+			Annotation: true,
+			Kinds:      []string{"partition"},
+			Reason:     "GovCloud only",
+		},
+		{
+			Name:    "other comments",
+			Comment: "# aws-meta:allowed region\n",
+		},
+		{
+			Name:       "missing kind",
+			Comment:    "# aws-meta:allow\n",
+			Annotation: true,
+			Err:        "missing finding kind",
+		},
+		{
+			Name:       "unknown kind",
+			Comment:    "# aws-meta:allow regions reason=x\n",
+			Annotation: true,
+			Err:        `unknown finding kind "regions", expected one of region, partition, availability_zone, account_id, ami_id, service_principal, dns_suffix, principal_suffix`,
+		},
+		{
+			Name:       "missing reason",
+			Comment:    "# aws-meta:allow region until=2027-01-01\n",
+			Annotation: true,
+			Err:        "missing reason",
+		},
+		{
+			Name:       "invalid date",
+			Comment:    "# aws-meta:allow region reason=x until=01/01/2027\n",
+			Annotation: true,
+			Err:        `invalid until date "01/01/2027", expected YYYY-MM-DD`,
+		},
+		{
+			Name:       "unknown option",
+			Comment:    "# aws-meta:allow region reason=x owner=me\n",
+			Annotation: true,
+			Err:        `unknown option "owner"`,
+		},
+		{
+			Name:       "unterminated quote",
+			Comment:    "# aws-meta:allow region reason=\"x\n",
+			Annotation: true,
+			Err:        "unterminated quote",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			rng := hcl.Range{Filename: "main.tf", Start: hcl.InitialPos, End: advancePos(hcl.InitialPos, test.Comment)}
+			a, ok := parseAnnotation(test.Comment, rng)
+			if ok != test.Annotation {
+				t.Fatalf("Expected annotation to be %v, got %v", test.Annotation, ok)
+			}
+			if !ok {
+				return
+			}
+
+			if a.Range.End.Line != 1 {
+				t.Errorf("Expected the range to end on line 1, got %d", a.Range.End.Line)
+			}
+			if test.Err != "" {
+				if a.Err == nil || a.Err.Error() != test.Err {
+					t.Errorf("Expected error %q, got %v", test.Err, a.Err)
+				}
+				return
+			}
+			if a.Err != nil {
+				t.Fatalf("Unexpected error occurred: %s", a.Err)
+			}
+			if !slices.Equal(a.Kinds, test.Kinds) {
+				t.Errorf("Expected kinds %v, got %v", test.Kinds, a.Kinds)
+			}
+			if a.Reason != test.Reason {
+				t.Errorf("Expected reason %q, got %q", test.Reason, a.Reason)
+			}
+			until := ""
+			if !a.Until.IsZero() {
+				until = a.Until.Format(time.DateOnly)
+			}
+			if until != test.Until {
+				t.Errorf("Expected until %q, got %q", test.Until, until)
+			}
+		})
+	}
+}
+
+func Test_AnnotationsAllowIssues(t *testing.T) {
+	content := `
+resource "aws_cloudfront_distribution" "test" {
+  # aws-meta:allow region reason="CloudFront requires us-east-1" until=2027-01-01
+  region = "us-east-1"
+  tags = {
+    Zone    = "eu-west-2a" # aws-meta:allow availability_zone reason=legacy until=2026-01-01
+    Backup  = "eu-west-1" # aws-meta:allow partition reason="wrong kind"
+    Replica = "eu-west-3"
+  }
+}
+
+# aws-meta:allow region reason=typo until=tomorrow`
+
+	defer func(now func() time.Time) { timeNow = now }(timeNow)
+	timeNow = func() time.Time { return time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC) }
+
+	metaRule := NewAwsMetaHardcodedRule()
+	annotationRule := NewAwsMetaAnnotationRule()
+	ruleset := &RuleSet{BuiltinRuleSet: tflint.BuiltinRuleSet{
+		Rules: []tflint.Rule{metaRule, annotationRule},
+	}}
+	if err := ruleset.ApplyGlobalConfig(&tflint.Config{}); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	applyPluginConfig(t, ruleset, ``)
+
+	testRunner := helper.TestRunner(t, map[string]string{"main.tf": content})
+	runner, err := ruleset.NewRunner(testRunner)
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	for _, rule := range ruleset.EnabledRules {
+		if err := rule.Check(runner); err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+	}
+
+	helper.AssertIssues(t, helper.Issues{
+		{
+			Rule:    metaRule,
+			Message: "[AWSMETA003 kind=availability_zone value=eu-west-2a address=aws_cloudfront_distribution.test replacement=data.aws_availability_zones.available.names] Hardcoded AWS availability zone 'eu-west-2a' found. Consider using data.aws_availability_zones to dynamically fetch AZs for the current region (aws-meta:allow expired on 2026-01-01)",
+			Range: hcl.Range{
+				Filename: "main.tf",
+				Start:    hcl.Pos{Line: 6, Column: 16},
+				End:      hcl.Pos{Line: 6, Column: 26},
+			},
+		},
+		{
+			Rule:    metaRule,
+			Message: "[AWSMETA001 kind=region value=eu-west-1 address=aws_cloudfront_distribution.test replacement=data.aws_region.current.name] Hardcoded AWS region 'eu-west-1' found. Consider using data.aws_region.current.name",
+			Range: hcl.Range{
+				Filename: "main.tf",
+				Start:    hcl.Pos{Line: 7, Column: 16},
+				End:      hcl.Pos{Line: 7, Column: 25},
+			},
+		},
+		{
+			Rule:    metaRule,
+			Message: "[AWSMETA001 kind=region value=eu-west-3 address=aws_cloudfront_distribution.test replacement=data.aws_region.current.name] Hardcoded AWS region 'eu-west-3' found. Consider using data.aws_region.current.name",
+			Range: hcl.Range{
+				Filename: "main.tf",
+				Start:    hcl.Pos{Line: 8, Column: 16},
+				End:      hcl.Pos{Line: 8, Column: 25},
+			},
+		},
+		{
+			Rule:    annotationRule,
+			Message: "[AWSMETA102] Unused aws-meta:allow annotation. No hardcoded value it allows is reported on this line or the next",
+			Range: hcl.Range{
+				Filename: "main.tf",
+				Start:    hcl.Pos{Line: 7, Column: 27},
+				End:      hcl.Pos{Line: 7, Column: 73},
+			},
+		},
+		{
+			Rule:    annotationRule,
+			Message: `[AWSMETA101] Invalid aws-meta:allow annotation: invalid until date "tomorrow", expected YYYY-MM-DD`,
+			Range: hcl.Range{
+				Filename: "main.tf",
+				Start:    hcl.Pos{Line: 12, Column: 1},
+				End:      hcl.Pos{Line: 12, Column: 51},
+			},
+		},
+	}, testRunner.Issues)
+}
@@ -11,6 +11,7 @@ import (
 // AwsHardcodedIDsRule checks for hardcoded AWS account IDs and AMI IDs
 type AwsHardcodedIDsRule struct {
 	tflint.DefaultRule
+	withCatalog
 }
 
 // NewAwsHardcodedIDsRule returns a new rule
@@ -40,71 +41,53 @@ func (r *AwsHardcodedIDsRule) Link() string {
 
 // Check checks for hardcoded AWS account IDs and AMI IDs
 func (r *AwsHardcodedIDsRule) Check(runner tflint.Runner) error {
-	accountIDPattern := awsmeta.GetAccountIDPattern()
-	amiIDPattern := awsmeta.GetAMIIDPattern()
+	return scanExpressions(runner, r)
+}
 
-	files, err := runner.GetFiles()
+func (r *AwsHardcodedIDsRule) detectors() ([]Detector, error) {
+	matcher, err := r.matcher()
 	if err != nil {
-		return err
+		return nil, err
 	}
+	return []Detector{hardcodedIDDetector{matcher}}, nil
+}
 
-	checked := make(map[string]bool)
+// hardcodedIDDetector finds hardcoded account IDs and AMI IDs
+type hardcodedIDDetector struct {
+	matcher *awsmeta.Matcher
+}
 
-	diags := runner.WalkExpressions(tflint.ExprWalkFunc(func(expr hcl.Expression) hcl.Diagnostics {
-		exprKey := fmt.Sprintf("%s:%d:%d", expr.Range().Filename, expr.Range().Start.Line, expr.Range().Start.Column)
-		if checked[exprKey] {
-			return nil
-		}
-		checked[exprKey] = true
-
-		// Pre-filter on raw source text
-		exprRange := expr.Range()
-		if file, ok := files[exprRange.Filename]; ok {
-			src := file.Bytes
-			if exprRange.Start.Byte < len(src) && exprRange.End.Byte <= len(src) {
-				sourceText := string(src[exprRange.Start.Byte:exprRange.End.Byte])
-				hasAccountID := accountIDPattern.MatchString(sourceText)
-				hasAMI := amiIDPattern.MatchString(sourceText)
-				if !hasAccountID && !hasAMI {
-					return nil
-				}
-			}
+// Candidate pre-filters on the raw source text
+func (d hardcodedIDDetector) Candidate(expr hcl.Expression, src string) bool {
+	for _, hit := range d.matcher.Find(src) {
+		if hit.Kind == awsmeta.HitAccountID || hit.Kind == awsmeta.HitAMIID {
+			return true
 		}
+	}
+	return false
+}
+
+// Detect finds hardcoded account IDs and AMI IDs in the evaluated value
+func (d hardcodedIDDetector) Detect(value string) []Match {
+	var matches []Match
+
+	// Check for hardcoded account ID
+	if hit, ok := d.matcher.First(value, awsmeta.HitAccountID); ok {
+		matches = append(matches, Match{
+			Kind:    kindAccountID,
+			Value:   hit.Value,
+			Message: fmt.Sprintf("Hardcoded AWS account ID '%s' found. Consider using data.aws_caller_identity.current.account_id", hit.Value),
+		})
+	}
 
-		err := runner.EvaluateExpr(expr, func(value string) error {
-			// Check for hardcoded account ID
-			if matches := accountIDPattern.FindStringSubmatch(value); len(matches) > 1 {
-				if err := runner.EmitIssue(
-					r,
-					fmt.Sprintf("Hardcoded AWS account ID '%s' found. Consider using data.aws_caller_identity.current.account_id", matches[1]),
-					expr.Range(),
-				); err != nil {
-					return err
-				}
-			}
-
-			// Check for hardcoded AMI ID
-			if match := amiIDPattern.FindString(value); match != "" {
-				if err := runner.EmitIssue(
-					r,
-					fmt.Sprintf("Hardcoded AMI ID '%s' found. AMI IDs are region-specific. Consider using data.aws_ami to dynamically look up AMIs", match),
-					expr.Range(),
-				); err != nil {
-					return err
-				}
-			}
-
-			return nil
-		}, nil)
-
-		_ = err
-
-		return nil
-	}))
-
-	if diags.HasErrors() {
-		return diags
+	// Check for hardcoded AMI ID
+	if hit, ok := d.matcher.First(value, awsmeta.HitAMIID); ok {
+		matches = append(matches, Match{
+			Kind:    kindAMIID,
+			Value:   hit.Value,
+			Message: fmt.Sprintf("Hardcoded AMI ID '%s' found. AMI IDs are region-specific. Consider using data.aws_ami to dynamically look up AMIs", hit.Value),
+		})
 	}
 
-	return nil
+	return matches
 }
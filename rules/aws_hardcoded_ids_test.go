@@ -24,7 +24,7 @@ resource "aws_iam_role_policy" "test" {
     }]
   })
 }`,
-			ExpectedCount: 2,
+			ExpectedCount: 1,
 		},
 		{
 			Name: "hardcoded account ID as standalone value",
@@ -32,7 +32,7 @@ resource "aws_iam_role_policy" "test" {
 resource "aws_guardduty_member" "test" {
   account_id = "123456789012"
 }`,
-			ExpectedCount: 2,
+			ExpectedCount: 1,
 		},
 		{
 			Name: "hardcoded AMI ID",
@@ -41,7 +41,7 @@ resource "aws_instance" "test" {
   ami           = "ami-0abcdef1234567890"
   instance_type = "t3.micro"
 }`,
-			ExpectedCount: 2,
+			ExpectedCount: 1,
 		},
 		{
 			Name: "hardcoded AMI ID in launch template",
@@ -49,7 +49,7 @@ resource "aws_instance" "test" {
 resource "aws_launch_template" "test" {
   image_id = "ami-0ff8a91507f77f867"
 }`,
-			ExpectedCount: 2,
+			ExpectedCount: 1,
 		},
 		{
 			Name: "both account ID and AMI in same config",
@@ -61,7 +61,7 @@ resource "aws_instance" "test" {
 resource "aws_guardduty_member" "test" {
   account_id = "123456789012"
 }`,
-			ExpectedCount: 4,
+			ExpectedCount: 2,
 		},
 		{
 			Name: "dynamic account ID using data source",
@@ -85,7 +85,7 @@ resource "aws_instance" "test" {
   ami           = data.aws_ami.ubuntu.id
   instance_type = "t3.micro"
 }`,
-			ExpectedCount: 2, // the owners value is a 12-digit account ID
+			ExpectedCount: 1, // the owners value is a 12-digit account ID
 		},
 		{
 			Name: "no hardcoded IDs",
@@ -126,3 +126,62 @@ resource "aws_instance" "test" {
 		})
 	}
 }
+
+func Test_AwsHardcodedIDsRule_AllowedValues(t *testing.T) {
+	tests := []struct {
+		Name          string
+		Config        string
+		Content       string
+		ExpectedCount int
+	}{
+		{
+			Name: "allowed account ID",
+			Config: `
+rule "aws_hardcoded_ids" {
+  enabled             = true
+  allowed_account_ids = ["123456789012"]
+}`,
+			Content: `
+resource "aws_guardduty_member" "shared" {
+  account_id = "123456789012"
+}
+
+resource "aws_guardduty_member" "other" {
+  account_id = "210987654321"
+}`,
+			ExpectedCount: 1,
+		},
+		{
+			Name: "allowed AMI",
+			Config: `
+rule "aws_hardcoded_ids" {
+  enabled      = true
+  allowed_amis = ["ami-0abcdef1234567890"]
+}`,
+			Content: `
+resource "aws_instance" "test" {
+  ami = "ami-0abcdef1234567890"
+}`,
+			ExpectedCount: 0,
+		},
+	}
+
+	rule := NewAwsHardcodedIDsRule()
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			runner := helper.TestRunner(t, map[string]string{"main.tf": test.Content, ".tflint.hcl": test.Config})
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			if len(runner.Issues) != test.ExpectedCount {
+				t.Errorf("Expected %d issues, got %d", test.ExpectedCount, len(runner.Issues))
+				for i, issue := range runner.Issues {
+					t.Logf("Issue %d: %s", i+1, issue.Message)
+				}
+			}
+		})
+	}
+}
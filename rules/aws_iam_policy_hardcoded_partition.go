@@ -1,20 +1,17 @@
 package rules
 
 import (
-	"encoding/json"
 	"fmt"
-	"regexp"
-	"strings"
 
 	"github.com/hashicorp/hcl/v2"
 	"github.com/myerscode/tflint-ruleset-aws-meta/rules/awsmeta"
-	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
 	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
 )
 
 // AwsIamPolicyHardcodedPartitionRule checks for hardcoded AWS partitions in IAM policies
 type AwsIamPolicyHardcodedPartitionRule struct {
 	tflint.DefaultRule
+	withCatalog
 }
 
 // NewAwsIamPolicyHardcodedPartitionRule returns a new rule
@@ -42,82 +39,102 @@ func (r *AwsIamPolicyHardcodedPartitionRule) Link() string {
 	return ""
 }
 
-// Check checks for hardcoded AWS partitions in IAM policies
+// Check checks for hardcoded AWS partitions in IAM policies and policy documents
 func (r *AwsIamPolicyHardcodedPartitionRule) Check(runner tflint.Runner) error {
-	resources, err := runner.GetResourceContent("aws_iam_policy", &hclext.BodySchema{
-		Attributes: []hclext.AttributeSchema{
-			{Name: "policy"},
-		},
-	}, nil)
+	matcher, err := r.matcher()
 	if err != nil {
 		return err
 	}
 
-	for _, resource := range resources.Blocks {
-		if attr, exists := resource.Body.Attributes["policy"]; exists {
-			err := runner.EvaluateExpr(attr.Expr, func(policy string) error {
-				return r.checkPolicyForHardcodedPartitions(runner, policy, attr.Expr.Range())
-			}, nil)
-			if err != nil && !strings.Contains(err.Error(), "cannot convert") {
-				return err
-			}
+	attributes, err := getPolicyAttributes(runner, func(resourceType string) bool {
+		return !isRolePolicy(resourceType)
+	})
+	if err != nil {
+		return err
+	}
+
+	config, err := decodeRuleConfig(runner, r)
+	if err != nil {
+		return err
+	}
+
+	files, err := runner.GetFiles()
+	if err != nil {
+		return err
+	}
+
+	for _, attr := range attributes {
+		err := evaluatePolicy(runner, files, attr.Expr, func(policy string, source *policySource) error {
+			return r.checkPolicyForHardcodedPartitions(runner, matcher, config, policy, source)
+		})
+		if err != nil {
+			return err
 		}
 	}
 
-	return nil
+	// Policies written as aws_iam_policy_document data sources
+	return r.checkPolicyDocumentDataSources(runner, matcher, config, files)
 }
 
-func (r *AwsIamPolicyHardcodedPartitionRule) checkPolicyForHardcodedPartitions(runner tflint.Runner, policy string, rng hcl.Range) error {
-	// Get dynamic pattern from aws-meta package
-	arnPartitionPattern := awsmeta.GetPartitionPattern()
+func (r *AwsIamPolicyHardcodedPartitionRule) checkPolicyForHardcodedPartitions(runner tflint.Runner, matcher *awsmeta.Matcher, config *ruleConfig, policy string, source *policySource) error {
+	// Check structured policy documents value by value
+	if doc, err := awsmeta.ParsePolicy(policy); err == nil {
+		return r.checkPolicyDocument(runner, matcher, config, doc, source)
+	}
 
-	// Try to parse as JSON to check structured policy
-	var policyDoc map[string]interface{}
-	if err := json.Unmarshal([]byte(policy), &policyDoc); err == nil {
-		// Check structured policy document
-		if err := r.checkPolicyDocument(runner, policyDoc, rng, arnPartitionPattern); err != nil {
+	// Check raw string for ARN patterns
+	for _, match := range findPolicyPartitions(matcher, policy) {
+		if config.allows(kindPartition, match.Partition) {
+			continue
+		}
+		if err := source.emit(runner, r, Match{
+			Kind:    kindPartition,
+			Value:   match.Partition,
+			Message: fmt.Sprintf("Hardcoded AWS partition '%s' found in ARN within IAM policy. Consider using data.aws_partition.current.partition", match.Partition),
+		}, source.textRange(kindPartition, match.Partition, match.Start, match.End)); err != nil {
 			return err
 		}
-	} else {
-		// Check raw string for ARN patterns
-		if matches := arnPartitionPattern.FindAllStringSubmatch(policy, -1); len(matches) > 0 {
-			for _, match := range matches {
-				if len(match) > 1 {
-					partition := match[1]
-					if err := runner.EmitIssue(
-						r,
-						fmt.Sprintf("Hardcoded AWS partition '%s' found in ARN within IAM policy. Consider using data.aws_partition.current.partition", partition),
-						rng,
-					); err != nil {
-						return err
-					}
-				}
-			}
+	}
+
+	return nil
+}
+
+func (r *AwsIamPolicyHardcodedPartitionRule) checkPolicyDocument(runner tflint.Runner, matcher *awsmeta.Matcher, config *ruleConfig, doc *awsmeta.Policy, source *policySource) error {
+	// Check for hardcoded partitions in ARNs within the policy document
+	for _, match := range findDocumentPartitions(matcher, doc) {
+		if config.allows(kindPartition, match.Partition) {
+			continue
+		}
+		if err := source.emit(runner, r, Match{
+			Kind:    kindPartition,
+			Value:   match.Partition,
+			Message: fmt.Sprintf("Hardcoded AWS partition '%s' found in ARN within IAM policy document. Consider using data.aws_partition.current.partition", match.Partition),
+		}, source.valueRange(match.Value, kindPartition, match.Start, match.End)); err != nil {
+			return err
 		}
 	}
 
 	return nil
 }
 
-func (r *AwsIamPolicyHardcodedPartitionRule) checkPolicyDocument(runner tflint.Runner, doc map[string]interface{}, rng hcl.Range, arnPartitionPattern *regexp.Regexp) error {
-	// Convert back to string to search for patterns
-	docBytes, err := json.Marshal(doc)
+func (r *AwsIamPolicyHardcodedPartitionRule) checkPolicyDocumentDataSources(runner tflint.Runner, matcher *awsmeta.Matcher, config *ruleConfig, files map[string]*hcl.File) error {
+	documents, err := getPolicyDocumentValues(runner, files)
 	if err != nil {
-		return nil // Skip if we can't marshal back
+		return err
 	}
 
-	docString := string(docBytes)
-
-	// Check for hardcoded partitions in ARNs within the policy document
-	if matches := arnPartitionPattern.FindAllStringSubmatch(docString, -1); len(matches) > 0 {
-		for _, match := range matches {
-			if len(match) > 1 {
-				partition := match[1]
-				if err := runner.EmitIssue(
-					r,
-					fmt.Sprintf("Hardcoded AWS partition '%s' found in ARN within IAM policy document. Consider using data.aws_partition.current.partition", partition),
-					rng,
-				); err != nil {
+	// Report the partition of every ARN, on the attribute holding it
+	for _, values := range documents {
+		for _, value := range values {
+			for _, match := range findPolicyPartitions(matcher, value.Value) {
+				if config.allows(kindPartition, match.Partition) {
+					continue
+				}
+				if err := emitIssue(runner, r, Match{
+					Kind:    kindPartition,
+					Value:   match.Partition,
+					Message: fmt.Sprintf("Hardcoded AWS partition '%s' found in ARN within aws_iam_policy_document. Consider using data.aws_partition.current.partition", match.Partition),
+				}, value.Locator.next(kindPartition, match.Partition)); err != nil {
 					return err
 				}
 			}
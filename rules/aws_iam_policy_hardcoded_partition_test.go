@@ -3,6 +3,7 @@ package rules
 import (
 	"testing"
 
+	"github.com/hashicorp/hcl/v2"
 	"github.com/terraform-linters/tflint-plugin-sdk/helper"
 )
 
@@ -27,6 +28,35 @@ resource "aws_iam_policy" "example" {
 resource "aws_iam_policy" "example" {
   name = "example-policy"
   policy = "some-policy-without-arn"
+}`,
+			ExpectedCount: 0,
+		},
+		{
+			Name: "aws_iam_policy_document data source",
+			Content: `
+data "aws_iam_policy_document" "example" {
+  statement {
+    actions   = ["s3:GetObject"]
+    resources = ["arn:aws:s3:::a/*", "arn:aws-cn:s3:::b/*"]
+
+    principals {
+      type        = "AWS"
+      identifiers = ["arn:aws:iam::123456789012:root"]
+    }
+  }
+}`,
+			ExpectedCount: 3,
+		},
+		{
+			Name: "aws_iam_policy_document data source with references",
+			Content: `
+variable "bucket_arn" {}
+
+data "aws_iam_policy_document" "example" {
+  statement {
+    actions   = ["s3:GetObject"]
+    resources = ["${var.bucket_arn}/*"]
+  }
 }`,
 			ExpectedCount: 0,
 		},
@@ -51,3 +81,209 @@ resource "aws_iam_policy" "example" {
 		})
 	}
 }
+
+func Test_AwsIamPolicyHardcodedPartitionRule_AllowedValues(t *testing.T) {
+	tests := []struct {
+		Name          string
+		Config        string
+		Content       string
+		ExpectedCount int
+	}{
+		{
+			Name: "allowed partition",
+			Config: `
+rule "aws_iam_policy_hardcoded_partition" {
+  enabled            = true
+  allowed_partitions = ["aws"]
+}`,
+			Content: `
+resource "aws_iam_policy" "example" {
+  name   = "example-policy"
+  policy = "arn:aws:s3:::my-bucket/*"
+}`,
+			ExpectedCount: 0,
+		},
+	}
+
+	rule := NewAwsIamPolicyHardcodedPartitionRule()
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			runner := helper.TestRunner(t, map[string]string{"main.tf": test.Content, ".tflint.hcl": test.Config})
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			if len(runner.Issues) != test.ExpectedCount {
+				t.Errorf("Expected %d issues, got %d", test.ExpectedCount, len(runner.Issues))
+				for i, issue := range runner.Issues {
+					t.Logf("Issue %d: %s", i+1, issue.Message)
+				}
+			}
+		})
+	}
+}
+
+func Test_AwsIamPolicyHardcodedPartitionRule_Ranges(t *testing.T) {
+	content := `
+resource "aws_iam_policy" "test" {
+  policy = <<EOF
+{
+  "Statement": [{
+    "Sid": "arn:aws:s3:::not-a-resource",
+    "Resource": [
+      "arn:aws:s3:::a",
+      "arn:aws:s3:::b"
+    ]
+  }]
+}
+EOF
+}`
+
+	rule := NewAwsIamPolicyHardcodedPartitionRule()
+	runner := helper.TestRunner(t, map[string]string{"main.tf": content})
+
+	if err := rule.Check(runner); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	message := "[AWSMETA002 kind=partition value=aws address=aws_iam_policy.test replacement=data.aws_partition.current.partition] Hardcoded AWS partition 'aws' found in ARN within IAM policy document. Consider using data.aws_partition.current.partition"
+	helper.AssertIssues(t, helper.Issues{
+		{
+			Rule:    rule,
+			Message: message,
+			Range: hcl.Range{
+				Filename: "main.tf",
+				Start:    hcl.Pos{Line: 8, Column: 12},
+				End:      hcl.Pos{Line: 8, Column: 15},
+			},
+		},
+		{
+			Rule:    rule,
+			Message: message,
+			Range: hcl.Range{
+				Filename: "main.tf",
+				Start:    hcl.Pos{Line: 9, Column: 12},
+				End:      hcl.Pos{Line: 9, Column: 15},
+			},
+		},
+	}, runner.Issues)
+}
+
+func Test_AwsIamPolicyHardcodedPartitionRule_PolicyDocumentRanges(t *testing.T) {
+	content := `
+data "aws_iam_policy_document" "example" {
+  statement {
+    sid       = "arn:aws:s3:::not-a-resource"
+    resources = ["arn:aws:s3:::a", "arn:aws:s3:::b"]
+  }
+}`
+
+	rule := NewAwsIamPolicyHardcodedPartitionRule()
+	runner := helper.TestRunner(t, map[string]string{"main.tf": content})
+
+	if err := rule.Check(runner); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	message := "[AWSMETA002 kind=partition value=aws address=data.aws_iam_policy_document.example replacement=data.aws_partition.current.partition] Hardcoded AWS partition 'aws' found in ARN within aws_iam_policy_document. Consider using data.aws_partition.current.partition"
+	helper.AssertIssues(t, helper.Issues{
+		{
+			Rule:    rule,
+			Message: message,
+			Range: hcl.Range{
+				Filename: "main.tf",
+				Start:    hcl.Pos{Line: 5, Column: 23},
+				End:      hcl.Pos{Line: 5, Column: 26},
+			},
+		},
+		{
+			Rule:    rule,
+			Message: message,
+			Range: hcl.Range{
+				Filename: "main.tf",
+				Start:    hcl.Pos{Line: 5, Column: 41},
+				End:      hcl.Pos{Line: 5, Column: 44},
+			},
+		},
+	}, runner.Issues)
+}
+
+func Test_AwsIamPolicyHardcodedPartitionRule_JsonencodeRanges(t *testing.T) {
+	content := `
+variable "bucket_name" {}
+
+resource "aws_iam_policy" "test" {
+  policy = jsonencode({
+    Statement = [{
+      Effect   = "Allow"
+      Action   = "s3:GetObject"
+      Resource = ["arn:aws:s3:::${var.bucket_name}/*", "arn:aws:s3:::logs/*"]
+    }]
+  })
+}`
+
+	rule := NewAwsIamPolicyHardcodedPartitionRule()
+	runner := helper.TestRunner(t, map[string]string{"main.tf": content})
+
+	if err := rule.Check(runner); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	helper.AssertIssues(t, helper.Issues{
+		{
+			Rule:    rule,
+			Message: "[AWSMETA002 kind=partition value=aws address=aws_iam_policy.test replacement=data.aws_partition.current.partition] Hardcoded AWS partition 'aws' found in ARN within IAM policy document. Consider using data.aws_partition.current.partition",
+			Range: hcl.Range{
+				Filename: "main.tf",
+				Start:    hcl.Pos{Line: 9, Column: 61},
+				End:      hcl.Pos{Line: 9, Column: 64},
+			},
+		},
+	}, runner.Issues)
+}
+
+func Test_AwsIamPolicyHardcodedPartitionRule_TemplateRanges(t *testing.T) {
+	content := `
+variable "bucket_name" {}
+
+resource "aws_iam_policy" "test" {
+  policy = <<EOF
+{
+  "Statement": [{
+    "Sid": "arn:aws:s3:::not-a-resource",
+    "Resource": ["arn:aws:s3:::${var.bucket_name}/*", "arn:aws-cn:s3:::logs/*"]
+  }]
+}
+EOF
+}`
+
+	rule := NewAwsIamPolicyHardcodedPartitionRule()
+	runner := helper.TestRunner(t, map[string]string{"main.tf": content})
+
+	if err := rule.Check(runner); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	helper.AssertIssues(t, helper.Issues{
+		{
+			Rule:    rule,
+			Message: "[AWSMETA002 kind=partition value=aws address=aws_iam_policy.test replacement=data.aws_partition.current.partition] Hardcoded AWS partition 'aws' found in ARN within IAM policy document. Consider using data.aws_partition.current.partition",
+			Range: hcl.Range{
+				Filename: "main.tf",
+				Start:    hcl.Pos{Line: 9, Column: 23},
+				End:      hcl.Pos{Line: 9, Column: 26},
+			},
+		},
+		{
+			Rule:    rule,
+			Message: "[AWSMETA002 kind=partition value=aws-cn address=aws_iam_policy.test replacement=data.aws_partition.current.partition] Hardcoded AWS partition 'aws-cn' found in ARN within IAM policy document. Consider using data.aws_partition.current.partition",
+			Range: hcl.Range{
+				Filename: "main.tf",
+				Start:    hcl.Pos{Line: 9, Column: 60},
+				End:      hcl.Pos{Line: 9, Column: 66},
+			},
+		},
+	}, runner.Issues)
+}
@@ -1,20 +1,17 @@
 package rules
 
 import (
-	"encoding/json"
 	"fmt"
-	"regexp"
-	"strings"
 
 	"github.com/hashicorp/hcl/v2"
 	"github.com/myerscode/tflint-ruleset-aws-meta/rules/awsmeta"
-	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
 	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
 )
 
 // AwsIamPolicyHardcodedRegionRule checks for hardcoded AWS regions in IAM policies
 type AwsIamPolicyHardcodedRegionRule struct {
 	tflint.DefaultRule
+	withCatalog
 }
 
 // NewAwsIamPolicyHardcodedRegionRule returns a new rule
@@ -42,107 +39,103 @@ func (r *AwsIamPolicyHardcodedRegionRule) Link() string {
 	return ""
 }
 
-// Check checks for hardcoded AWS regions in IAM policies
+// Check checks for hardcoded AWS regions in IAM policies and policy documents
 func (r *AwsIamPolicyHardcodedRegionRule) Check(runner tflint.Runner) error {
-	resources, err := runner.GetResourceContent("aws_iam_policy", &hclext.BodySchema{
-		Attributes: []hclext.AttributeSchema{
-			{Name: "policy"},
-		},
-	}, nil)
+	matcher, err := r.matcher()
 	if err != nil {
 		return err
 	}
 
-	for _, resource := range resources.Blocks {
-		if attr, exists := resource.Body.Attributes["policy"]; exists {
-			err := runner.EvaluateExpr(attr.Expr, func(policy string) error {
-				return r.checkPolicyForHardcodedRegions(runner, policy, attr.Expr.Range())
-			}, nil)
-			if err != nil && !strings.Contains(err.Error(), "cannot convert") {
-				return err
-			}
+	attributes, err := getPolicyAttributes(runner, func(resourceType string) bool {
+		return !isRolePolicy(resourceType)
+	})
+	if err != nil {
+		return err
+	}
+
+	config, err := decodeRuleConfig(runner, r)
+	if err != nil {
+		return err
+	}
+
+	files, err := runner.GetFiles()
+	if err != nil {
+		return err
+	}
+
+	for _, attr := range attributes {
+		err := evaluatePolicy(runner, files, attr.Expr, func(policy string, source *policySource) error {
+			return r.checkPolicyForHardcodedRegions(runner, matcher, config, policy, source)
+		})
+		if err != nil {
+			return err
 		}
 	}
 
-	return nil
+	// Policies written as aws_iam_policy_document data sources
+	return r.checkPolicyDocumentDataSources(runner, matcher, config, files)
 }
 
-func (r *AwsIamPolicyHardcodedRegionRule) checkPolicyForHardcodedRegions(runner tflint.Runner, policy string, rng hcl.Range) error {
-	// Get dynamic patterns from aws-meta package
-	regionInStringPattern := awsmeta.GetRegionInStringPattern()
-	arnRegionPattern := awsmeta.GetARNRegionPattern()
+func (r *AwsIamPolicyHardcodedRegionRule) checkPolicyForHardcodedRegions(runner tflint.Runner, matcher *awsmeta.Matcher, config *ruleConfig, policy string, source *policySource) error {
+	// Check structured policy documents value by value
+	if doc, err := awsmeta.ParsePolicy(policy); err == nil {
+		return r.checkPolicyDocument(runner, matcher, config, doc, source)
+	}
 
-	// Try to parse as JSON to check structured policy
-	var policyDoc map[string]interface{}
-	if err := json.Unmarshal([]byte(policy), &policyDoc); err == nil {
-		// Check structured policy document
-		if err := r.checkPolicyDocument(runner, policyDoc, rng, regionInStringPattern, arnRegionPattern); err != nil {
-			return err
+	// Check raw string for patterns, reporting every region
+	for _, match := range findPolicyRegions(matcher, policy) {
+		if config.allows(kindRegion, match.Region) {
+			continue
 		}
-	} else {
-		// Check raw string for patterns
-		if matches := regionInStringPattern.FindAllString(policy, -1); len(matches) > 0 {
-			for _, match := range matches {
-				if err := runner.EmitIssue(
-					r,
-					fmt.Sprintf("Hardcoded AWS region '%s' found in IAM policy. Consider using variables or data.aws_region.current.name", match),
-					rng,
-				); err != nil {
-					return err
-				}
-			}
+		message := fmt.Sprintf("Hardcoded AWS region '%s' found in IAM policy. Consider using variables or data.aws_region.current.name", match.Region)
+		if match.InARN {
+			message = fmt.Sprintf("Hardcoded AWS region '%s' found in ARN within IAM policy. Consider using variables or data.aws_region.current.name", match.Region)
+		}
+		if err := source.emit(runner, r, Match{Kind: kindRegion, Value: match.Region, Message: message}, source.textRange(kindRegion, match.Region, match.Start, match.End)); err != nil {
+			return err
 		}
+	}
 
-		if matches := arnRegionPattern.FindAllStringSubmatch(policy, -1); len(matches) > 0 {
-			for _, match := range matches {
-				if len(match) > 1 {
-					region := match[1]
-					if err := runner.EmitIssue(
-						r,
-						fmt.Sprintf("Hardcoded AWS region '%s' found in ARN within IAM policy. Consider using variables or data.aws_region.current.name", region),
-						rng,
-					); err != nil {
-						return err
-					}
-				}
-			}
+	return nil
+}
+
+func (r *AwsIamPolicyHardcodedRegionRule) checkPolicyDocument(runner tflint.Runner, matcher *awsmeta.Matcher, config *ruleConfig, doc *awsmeta.Policy, source *policySource) error {
+	// Check for hardcoded regions in the values of the policy document
+	for _, match := range findDocumentRegions(matcher, doc) {
+		if config.allows(kindRegion, match.Region) {
+			continue
+		}
+		message := fmt.Sprintf("Hardcoded AWS region '%s' found in IAM policy document. Consider using variables or data.aws_region.current.name", match.Region)
+		if match.InARN {
+			message = fmt.Sprintf("Hardcoded AWS region '%s' found in ARN within IAM policy document. Consider using variables or data.aws_region.current.name", match.Region)
+		}
+		if err := source.emit(runner, r, Match{Kind: kindRegion, Value: match.Region, Message: message}, source.valueRange(match.Value, kindRegion, match.Start, match.End)); err != nil {
+			return err
 		}
 	}
 
 	return nil
 }
 
-func (r *AwsIamPolicyHardcodedRegionRule) checkPolicyDocument(runner tflint.Runner, doc map[string]interface{}, rng hcl.Range, regionInStringPattern, arnRegionPattern *regexp.Regexp) error {
-	// Convert back to string to search for patterns
-	docBytes, err := json.Marshal(doc)
+func (r *AwsIamPolicyHardcodedRegionRule) checkPolicyDocumentDataSources(runner tflint.Runner, matcher *awsmeta.Matcher, config *ruleConfig, files map[string]*hcl.File) error {
+	documents, err := getPolicyDocumentValues(runner, files)
 	if err != nil {
-		return nil // Skip if we can't marshal back
+		return err
 	}
 
-	docString := string(docBytes)
-
-	// Check for hardcoded regions in the policy document
-	if matches := regionInStringPattern.FindAllString(docString, -1); len(matches) > 0 {
-		for _, match := range matches {
-			if err := runner.EmitIssue(
-				r,
-				fmt.Sprintf("Hardcoded AWS region '%s' found in IAM policy document. Consider using variables or data.aws_region.current.name", match),
-				rng,
-			); err != nil {
-				return err
-			}
-		}
-	}
+	// Report every region, on the attribute holding it
+	for _, values := range documents {
+		for _, value := range values {
+			for _, match := range findPolicyRegions(matcher, value.Value) {
+				if config.allows(kindRegion, match.Region) {
+					continue
+				}
 
-	if matches := arnRegionPattern.FindAllStringSubmatch(docString, -1); len(matches) > 0 {
-		for _, match := range matches {
-			if len(match) > 1 {
-				region := match[1]
-				if err := runner.EmitIssue(
-					r,
-					fmt.Sprintf("Hardcoded AWS region '%s' found in ARN within IAM policy document. Consider using variables or data.aws_region.current.name", region),
-					rng,
-				); err != nil {
+				message := fmt.Sprintf("Hardcoded AWS region '%s' found in aws_iam_policy_document. Consider using variables or data.aws_region.current.name", match.Region)
+				if match.InARN {
+					message = fmt.Sprintf("Hardcoded AWS region '%s' found in ARN within aws_iam_policy_document. Consider using variables or data.aws_region.current.name", match.Region)
+				}
+				if err := emitIssue(runner, r, Match{Kind: kindRegion, Value: match.Region, Message: message}, value.Locator.next(kindRegion, match.Region)); err != nil {
 					return err
 				}
 			}
@@ -1,8 +1,10 @@
 package rules
 
 import (
+	"os"
 	"testing"
 
+	"github.com/hashicorp/hcl/v2"
 	"github.com/terraform-linters/tflint-plugin-sdk/helper"
 )
 
@@ -30,6 +32,105 @@ resource "aws_iam_policy" "example" {
 }`,
 			ExpectedCount: 0,
 		},
+		{
+			Name: "region in ARN is reported once",
+			Content: `
+resource "aws_iam_policy" "example" {
+  name = "example-policy"
+  policy = <<EOF
+{
+  "Statement": [{
+    "Effect": "Allow",
+    "Action": "sqs:SendMessage",
+    "Resource": "arn:aws:sqs:eu-west-1:123456789012:my-queue"
+  }]
+}
+EOF
+}`,
+			ExpectedCount: 1,
+		},
+		{
+			Name: "region in a Sid is ignored",
+			Content: `
+resource "aws_iam_policy" "example" {
+  name = "example-policy"
+  policy = <<EOF
+{
+  "Statement": [{
+    "Sid": "AllowQueuesInEuWest1",
+    "Effect": "Allow",
+    "Action": "sqs:SendMessage",
+    "Resource": "*"
+  }, {
+    "Sid": "eu-west-1",
+    "Effect": "Allow",
+    "Action": "sqs:SendMessage",
+    "Resource": "*"
+  }]
+}
+EOF
+}`,
+			ExpectedCount: 0,
+		},
+		{
+			Name: "aws_iam_policy_document data source",
+			Content: `
+data "aws_iam_policy_document" "example" {
+  statement {
+    sid       = "AllowEuWest1"
+    actions   = ["sqs:SendMessage"]
+    resources = ["arn:aws:sqs:eu-west-1:123456789012:a", "arn:aws:sqs:eu-west-1:123456789012:b", "arn:aws:sqs:us-east-1:123456789012:c"]
+
+    condition {
+      test     = "StringEquals"
+      variable = "aws:RequestedRegion"
+      values   = ["eu-west-2"]
+    }
+  }
+}`,
+			ExpectedCount: 4,
+		},
+		{
+			Name: "other policy-bearing resources",
+			Content: `
+resource "aws_s3_bucket_policy" "example" {
+  bucket = "example"
+  policy = <<EOF
+{"Statement": [{"Effect": "Allow", "Resource": "arn:aws:s3:::example/*", "Condition": {"StringEquals": {"aws:RequestedRegion": "eu-west-1"}}}]}
+EOF
+}
+
+resource "aws_iam_role" "example" {
+  assume_role_policy = <<EOF
+{"Statement": [{"Effect": "Allow", "Resource": "arn:aws:sqs:us-east-1:123456789012:queue"}]}
+EOF
+}
+
+resource "aws_iam_role_policy" "example" {
+  policy = <<EOF
+{"Statement": [{"Effect": "Allow", "Resource": "arn:aws:sqs:eu-west-2:123456789012:queue"}]}
+EOF
+}`,
+			ExpectedCount: 2,
+		},
+		{
+			Name: "policy templates with references that can't be resolved",
+			Content: `
+resource "aws_iam_policy" "example" {
+  for_each = toset(["app"])
+
+  policy = <<EOF
+{
+  "Statement": [{
+    "Effect": "Allow",
+    "Action": ["s3:GetObject", "sqs:SendMessage"],
+    "Resource": ["${aws_s3_bucket.logs.arn}/${each.key}/*", "arn:aws:sqs:eu-west-1:123456789012:queue"]
+  }]
+}
+EOF
+}`,
+			ExpectedCount: 1,
+		},
 	}
 
 	rule := NewAwsIamPolicyHardcodedRegionRule()
@@ -51,3 +152,80 @@ resource "aws_iam_policy" "example" {
 		})
 	}
 }
+
+func Test_AwsIamPolicyHardcodedRegionRule_PolicyDocumentRanges(t *testing.T) {
+	content := `
+data "aws_iam_policy_document" "example" {
+  statement {
+    resources = ["arn:aws:sqs:eu-west-1:123456789012:a", "arn:aws:sqs:eu-west-1:123456789012:b"]
+  }
+}`
+
+	rule := NewAwsIamPolicyHardcodedRegionRule()
+	runner := helper.TestRunner(t, map[string]string{"main.tf": content})
+
+	if err := rule.Check(runner); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	message := "[AWSMETA001 kind=region value=eu-west-1 address=data.aws_iam_policy_document.example replacement=data.aws_region.current.name] Hardcoded AWS region 'eu-west-1' found in ARN within aws_iam_policy_document. Consider using variables or data.aws_region.current.name"
+	helper.AssertIssues(t, helper.Issues{
+		{
+			Rule:    rule,
+			Message: message,
+			Range: hcl.Range{
+				Filename: "main.tf",
+				Start:    hcl.Pos{Line: 4, Column: 31},
+				End:      hcl.Pos{Line: 4, Column: 40},
+			},
+		},
+		{
+			Rule:    rule,
+			Message: message,
+			Range: hcl.Range{
+				Filename: "main.tf",
+				Start:    hcl.Pos{Line: 4, Column: 71},
+				End:      hcl.Pos{Line: 4, Column: 80},
+			},
+		},
+	}, runner.Issues)
+}
+
+func Test_AwsIamPolicyHardcodedRegionRule_ExternalFile(t *testing.T) {
+	t.Chdir(t.TempDir())
+	policy := `{
+  "Statement": [{
+    "Effect": "Allow",
+    "Action": "sqs:SendMessage",
+    "Resource": "arn:aws:sqs:eu-west-1:${account_id}:${queue_name}"
+  }]
+}
+`
+	if err := os.WriteFile("policy.json.tpl", []byte(policy), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	content := `
+resource "aws_iam_policy" "test" {
+  policy = templatefile("${path.module}/policy.json.tpl", { account_id = "123456789012", queue_name = "jobs" })
+}`
+
+	rule := NewAwsIamPolicyHardcodedRegionRule()
+	runner := helper.TestRunner(t, map[string]string{"main.tf": content})
+
+	if err := rule.Check(runner); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	helper.AssertIssues(t, helper.Issues{
+		{
+			Rule:    rule,
+			Message: "[AWSMETA001 kind=region value=eu-west-1 address=aws_iam_policy.test replacement=data.aws_region.current.name] Hardcoded AWS region 'eu-west-1' found in ARN within IAM policy document. Consider using variables or data.aws_region.current.name (in policy.json.tpl line 5)",
+			Range: hcl.Range{
+				Filename: "main.tf",
+				Start:    hcl.Pos{Line: 3, Column: 12},
+				End:      hcl.Pos{Line: 3, Column: 112},
+			},
+		},
+	}, runner.Issues)
+}
@@ -1,20 +1,16 @@
 package rules
 
 import (
-	"encoding/json"
 	"fmt"
-	"regexp"
-	"strings"
 
-	"github.com/hashicorp/hcl/v2"
 	"github.com/myerscode/tflint-ruleset-aws-meta/rules/awsmeta"
-	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
 	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
 )
 
 // AwsIamRolePolicyHardcodedPartitionRule checks for hardcoded AWS partitions in IAM role policies
 type AwsIamRolePolicyHardcodedPartitionRule struct {
 	tflint.DefaultRule
+	withCatalog
 }
 
 // NewAwsIamRolePolicyHardcodedPartitionRule returns a new rule
@@ -44,83 +40,73 @@ func (r *AwsIamRolePolicyHardcodedPartitionRule) Link() string {
 
 // Check checks for hardcoded AWS partitions in IAM role policies
 func (r *AwsIamRolePolicyHardcodedPartitionRule) Check(runner tflint.Runner) error {
-	resources, err := runner.GetResourceContent("aws_iam_role_policy", &hclext.BodySchema{
-		Attributes: []hclext.AttributeSchema{
-			{Name: "policy"},
-		},
-	}, nil)
+	matcher, err := r.matcher()
 	if err != nil {
 		return err
 	}
 
-	for _, resource := range resources.Blocks {
-		if attr, exists := resource.Body.Attributes["policy"]; exists {
-			err := runner.EvaluateExpr(attr.Expr, func(policy string) error {
-				return r.checkPolicyForHardcodedPartitions(runner, policy, attr.Expr.Range())
-			}, nil)
-			if err != nil && !strings.Contains(err.Error(), "cannot convert") {
-				return err
-			}
-		}
+	attributes, err := getPolicyAttributes(runner, isRolePolicy)
+	if err != nil {
+		return err
 	}
 
-	return nil
-}
+	config, err := decodeRuleConfig(runner, r)
+	if err != nil {
+		return err
+	}
 
-func (r *AwsIamRolePolicyHardcodedPartitionRule) checkPolicyForHardcodedPartitions(runner tflint.Runner, policy string, rng hcl.Range) error {
-	// Get dynamic pattern from aws-meta package
-	arnPartitionPattern := awsmeta.GetPartitionPattern()
+	files, err := runner.GetFiles()
+	if err != nil {
+		return err
+	}
 
-	// Try to parse as JSON to check structured policy
-	var policyDoc map[string]interface{}
-	if err := json.Unmarshal([]byte(policy), &policyDoc); err == nil {
-		// Check structured policy document
-		if err := r.checkPolicyDocument(runner, policyDoc, rng, arnPartitionPattern); err != nil {
+	for _, attr := range attributes {
+		err := evaluatePolicy(runner, files, attr.Expr, func(policy string, source *policySource) error {
+			return r.checkPolicyForHardcodedPartitions(runner, matcher, config, policy, source)
+		})
+		if err != nil {
 			return err
 		}
-	} else {
-		// Check raw string for ARN patterns
-		if matches := arnPartitionPattern.FindAllStringSubmatch(policy, -1); len(matches) > 0 {
-			for _, match := range matches {
-				if len(match) > 1 {
-					partition := match[1]
-					if err := runner.EmitIssue(
-						r,
-						fmt.Sprintf("Hardcoded AWS partition '%s' found in ARN within IAM role policy. Consider using data.aws_partition.current.partition", partition),
-						rng,
-					); err != nil {
-						return err
-					}
-				}
-			}
-		}
 	}
 
 	return nil
 }
 
-func (r *AwsIamRolePolicyHardcodedPartitionRule) checkPolicyDocument(runner tflint.Runner, doc map[string]interface{}, rng hcl.Range, arnPartitionPattern *regexp.Regexp) error {
-	// Convert back to string to search for patterns
-	docBytes, err := json.Marshal(doc)
-	if err != nil {
-		return nil // Skip if we can't marshal back
+func (r *AwsIamRolePolicyHardcodedPartitionRule) checkPolicyForHardcodedPartitions(runner tflint.Runner, matcher *awsmeta.Matcher, config *ruleConfig, policy string, source *policySource) error {
+	// Check structured policy documents value by value
+	if doc, err := awsmeta.ParsePolicy(policy); err == nil {
+		return r.checkPolicyDocument(runner, matcher, config, doc, source)
 	}
 
-	docString := string(docBytes)
+	// Check raw string for ARN patterns
+	for _, match := range findPolicyPartitions(matcher, policy) {
+		if config.allows(kindPartition, match.Partition) {
+			continue
+		}
+		if err := source.emit(runner, r, Match{
+			Kind:    kindPartition,
+			Value:   match.Partition,
+			Message: fmt.Sprintf("Hardcoded AWS partition '%s' found in ARN within IAM policy. Consider using data.aws_partition.current.partition", match.Partition),
+		}, source.textRange(kindPartition, match.Partition, match.Start, match.End)); err != nil {
+			return err
+		}
+	}
 
+	return nil
+}
+
+func (r *AwsIamRolePolicyHardcodedPartitionRule) checkPolicyDocument(runner tflint.Runner, matcher *awsmeta.Matcher, config *ruleConfig, doc *awsmeta.Policy, source *policySource) error {
 	// Check for hardcoded partitions in ARNs within the policy document
-	if matches := arnPartitionPattern.FindAllStringSubmatch(docString, -1); len(matches) > 0 {
-		for _, match := range matches {
-			if len(match) > 1 {
-				partition := match[1]
-				if err := runner.EmitIssue(
-					r,
-					fmt.Sprintf("Hardcoded AWS partition '%s' found in ARN within IAM role policy document. Consider using data.aws_partition.current.partition", partition),
-					rng,
-				); err != nil {
-					return err
-				}
-			}
+	for _, match := range findDocumentPartitions(matcher, doc) {
+		if config.allows(kindPartition, match.Partition) {
+			continue
+		}
+		if err := source.emit(runner, r, Match{
+			Kind:    kindPartition,
+			Value:   match.Partition,
+			Message: fmt.Sprintf("Hardcoded AWS partition '%s' found in ARN within IAM policy document. Consider using data.aws_partition.current.partition", match.Partition),
+		}, source.valueRange(match.Value, kindPartition, match.Start, match.End)); err != nil {
+			return err
 		}
 	}
 
@@ -1,20 +1,16 @@
 package rules
 
 import (
-	"encoding/json"
 	"fmt"
-	"regexp"
-	"strings"
 
-	"github.com/hashicorp/hcl/v2"
 	"github.com/myerscode/tflint-ruleset-aws-meta/rules/awsmeta"
-	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
 	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
 )
 
 // AwsIamRolePolicyHardcodedRegionRule checks for hardcoded AWS regions in IAM role policies
 type AwsIamRolePolicyHardcodedRegionRule struct {
 	tflint.DefaultRule
+	withCatalog
 }
 
 // NewAwsIamRolePolicyHardcodedRegionRule returns a new rule
@@ -44,108 +40,73 @@ func (r *AwsIamRolePolicyHardcodedRegionRule) Link() string {
 
 // Check checks for hardcoded AWS regions in IAM role policies
 func (r *AwsIamRolePolicyHardcodedRegionRule) Check(runner tflint.Runner) error {
-	resources, err := runner.GetResourceContent("aws_iam_role_policy", &hclext.BodySchema{
-		Attributes: []hclext.AttributeSchema{
-			{Name: "policy"},
-		},
-	}, nil)
+	matcher, err := r.matcher()
 	if err != nil {
 		return err
 	}
 
-	for _, resource := range resources.Blocks {
-		if attr, exists := resource.Body.Attributes["policy"]; exists {
-			err := runner.EvaluateExpr(attr.Expr, func(policy string) error {
-				return r.checkPolicyForHardcodedRegions(runner, policy, attr.Expr.Range())
-			}, nil)
-			if err != nil && !strings.Contains(err.Error(), "cannot convert") {
-				return err
-			}
-		}
+	attributes, err := getPolicyAttributes(runner, isRolePolicy)
+	if err != nil {
+		return err
 	}
 
-	return nil
-}
+	config, err := decodeRuleConfig(runner, r)
+	if err != nil {
+		return err
+	}
 
-func (r *AwsIamRolePolicyHardcodedRegionRule) checkPolicyForHardcodedRegions(runner tflint.Runner, policy string, rng hcl.Range) error {
-	// Get dynamic patterns from aws-meta package
-	regionInStringPattern := awsmeta.GetRegionInStringPattern()
-	arnRegionPattern := awsmeta.GetARNRegionPattern()
+	files, err := runner.GetFiles()
+	if err != nil {
+		return err
+	}
 
-	// Try to parse as JSON to check structured policy
-	var policyDoc map[string]interface{}
-	if err := json.Unmarshal([]byte(policy), &policyDoc); err == nil {
-		// Check structured policy document
-		if err := r.checkPolicyDocument(runner, policyDoc, rng, regionInStringPattern, arnRegionPattern); err != nil {
+	for _, attr := range attributes {
+		err := evaluatePolicy(runner, files, attr.Expr, func(policy string, source *policySource) error {
+			return r.checkPolicyForHardcodedRegions(runner, matcher, config, policy, source)
+		})
+		if err != nil {
 			return err
 		}
-	} else {
-		// Check raw string for patterns
-		if matches := regionInStringPattern.FindAllString(policy, -1); len(matches) > 0 {
-			for _, match := range matches {
-				if err := runner.EmitIssue(
-					r,
-					fmt.Sprintf("Hardcoded AWS region '%s' found in IAM role policy. Consider using variables or data.aws_region.current.name", match),
-					rng,
-				); err != nil {
-					return err
-				}
-			}
-		}
-
-		if matches := arnRegionPattern.FindAllStringSubmatch(policy, -1); len(matches) > 0 {
-			for _, match := range matches {
-				if len(match) > 1 {
-					region := match[1]
-					if err := runner.EmitIssue(
-						r,
-						fmt.Sprintf("Hardcoded AWS region '%s' found in ARN within IAM role policy. Consider using variables or data.aws_region.current.name", region),
-						rng,
-					); err != nil {
-						return err
-					}
-				}
-			}
-		}
 	}
 
 	return nil
 }
 
-func (r *AwsIamRolePolicyHardcodedRegionRule) checkPolicyDocument(runner tflint.Runner, doc map[string]interface{}, rng hcl.Range, regionInStringPattern, arnRegionPattern *regexp.Regexp) error {
-	// Convert back to string to search for patterns
-	docBytes, err := json.Marshal(doc)
-	if err != nil {
-		return nil // Skip if we can't marshal back
+func (r *AwsIamRolePolicyHardcodedRegionRule) checkPolicyForHardcodedRegions(runner tflint.Runner, matcher *awsmeta.Matcher, config *ruleConfig, policy string, source *policySource) error {
+	// Check structured policy documents value by value
+	if doc, err := awsmeta.ParsePolicy(policy); err == nil {
+		return r.checkPolicyDocument(runner, matcher, config, doc, source)
 	}
 
-	docString := string(docBytes)
-
-	// Check for hardcoded regions in the policy document
-	if matches := regionInStringPattern.FindAllString(docString, -1); len(matches) > 0 {
-		for _, match := range matches {
-			if err := runner.EmitIssue(
-				r,
-				fmt.Sprintf("Hardcoded AWS region '%s' found in IAM role policy document. Consider using variables or data.aws_region.current.name", match),
-				rng,
-			); err != nil {
-				return err
-			}
+	// Check raw string for patterns, reporting every region
+	for _, match := range findPolicyRegions(matcher, policy) {
+		if config.allows(kindRegion, match.Region) {
+			continue
+		}
+		message := fmt.Sprintf("Hardcoded AWS region '%s' found in IAM policy. Consider using variables or data.aws_region.current.name", match.Region)
+		if match.InARN {
+			message = fmt.Sprintf("Hardcoded AWS region '%s' found in ARN within IAM policy. Consider using variables or data.aws_region.current.name", match.Region)
+		}
+		if err := source.emit(runner, r, Match{Kind: kindRegion, Value: match.Region, Message: message}, source.textRange(kindRegion, match.Region, match.Start, match.End)); err != nil {
+			return err
 		}
 	}
 
-	if matches := arnRegionPattern.FindAllStringSubmatch(docString, -1); len(matches) > 0 {
-		for _, match := range matches {
-			if len(match) > 1 {
-				region := match[1]
-				if err := runner.EmitIssue(
-					r,
-					fmt.Sprintf("Hardcoded AWS region '%s' found in ARN within IAM role policy document. Consider using variables or data.aws_region.current.name", region),
-					rng,
-				); err != nil {
-					return err
-				}
-			}
+	return nil
+}
+
+func (r *AwsIamRolePolicyHardcodedRegionRule) checkPolicyDocument(runner tflint.Runner, matcher *awsmeta.Matcher, config *ruleConfig, doc *awsmeta.Policy, source *policySource) error {
+	// Check for hardcoded regions in the values of the policy document
+	for _, match := range findDocumentRegions(matcher, doc) {
+		if config.allows(kindRegion, match.Region) {
+			continue
+		}
+		message := fmt.Sprintf("Hardcoded AWS region '%s' found in IAM policy document. Consider using variables or data.aws_region.current.name", match.Region)
+		if match.InARN {
+			message = fmt.Sprintf("Hardcoded AWS region '%s' found in ARN within IAM policy document. Consider using variables or data.aws_region.current.name", match.Region)
+		}
+		if err := source.emit(runner, r, Match{Kind: kindRegion, Value: match.Region, Message: message}, source.valueRange(match.Value, kindRegion, match.Start, match.End)); err != nil {
+			return err
 		}
 	}
 
@@ -29,6 +29,91 @@ resource "aws_iam_role_policy" "example" {
   name = "example-policy"
   role = "example-role"
   policy = "arn:aws:s3:::my-bucket/variable-region/*"
+}`,
+			ExpectedCount: 0,
+		},
+		{
+			Name: "region in ARN is reported once",
+			Content: `
+resource "aws_iam_role_policy" "example" {
+  name = "example-policy"
+  role = "example-role"
+  policy = <<EOF
+{
+  "Statement": [{
+    "Effect": "Allow",
+    "Action": "sqs:SendMessage",
+    "Resource": "arn:aws:sqs:eu-west-1:123456789012:my-queue"
+  }]
+}
+EOF
+}`,
+			ExpectedCount: 1,
+		},
+		{
+			Name: "jsonencode policy with an unknown value",
+			Content: `
+variable "queue_name" {}
+
+resource "aws_iam_role_policy" "example" {
+  name = "example-policy"
+  policy = jsonencode({
+    Statement = [{
+      Effect   = "Allow"
+      Action   = "sqs:SendMessage"
+      Resource = [
+        "arn:aws:sqs:eu-west-1:123456789012:${var.queue_name}",
+        "arn:aws:sqs:us-east-1:123456789012:audit",
+      ]
+    }]
+  })
+}`,
+			ExpectedCount: 1,
+		},
+		{
+			Name: "heredoc policy with unknown interpolations",
+			Content: `
+variable "queue_name" {}
+variable "statements" {}
+
+resource "aws_iam_role_policy" "example" {
+  name = "example-policy"
+  policy = <<EOF
+{
+  "Statement": [
+    ${var.statements},
+    {
+      "Sid": "us-west-2",
+      "Effect": "Allow",
+      "Action": "sqs:SendMessage",
+      "Resource": "arn:aws:sqs:eu-west-1:123456789012:${var.queue_name}"
+    }
+  ]
+}
+EOF
+}`,
+			ExpectedCount: 1,
+		},
+		{
+			Name: "region in a Sid is ignored",
+			Content: `
+resource "aws_iam_role_policy" "example" {
+  name = "example-policy"
+  policy = <<EOF
+{
+  "Statement": [{
+    "Sid": "AllowQueuesInEuWest1",
+    "Effect": "Allow",
+    "Action": "sqs:SendMessage",
+    "Resource": "*"
+  }, {
+    "Sid": "eu-west-1",
+    "Effect": "Allow",
+    "Action": "sqs:SendMessage",
+    "Resource": "*"
+  }]
+}
+EOF
 }`,
 			ExpectedCount: 0,
 		},
@@ -0,0 +1,66 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// AwsMetaAnnotationRule checks aws-meta:allow annotations. It runs after the
+// other rules of the ruleset, so it knows which annotations they used.
+type AwsMetaAnnotationRule struct {
+	tflint.DefaultRule
+}
+
+// NewAwsMetaAnnotationRule returns a new rule
+func NewAwsMetaAnnotationRule() *AwsMetaAnnotationRule {
+	return &AwsMetaAnnotationRule{}
+}
+
+// Name returns the rule name
+func (r *AwsMetaAnnotationRule) Name() string {
+	return "aws_meta_annotation"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *AwsMetaAnnotationRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *AwsMetaAnnotationRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Link returns the rule reference link
+func (r *AwsMetaAnnotationRule) Link() string {
+	return ""
+}
+
+// Check reports malformed annotations, and annotations that allowed no issue
+// of the rules that ran before it. Use is only known within a check of the
+// ruleset, so run on its own the rule reports malformed annotations only.
+func (r *AwsMetaAnnotationRule) Check(runner tflint.Runner) error {
+	annotations, err := runnerAnnotations(runner)
+	if err != nil {
+		return err
+	}
+	_, tracked := runner.(*checkRunner)
+
+	for _, a := range annotations {
+		var message string
+		switch {
+		case a.Err != nil:
+			message = codedMessage(codeInvalidAnnotation, fmt.Sprintf("Invalid %s annotation: %s", annotationPrefix, a.Err))
+		case tracked && !a.used:
+			message = codedMessage(codeUnusedAnnotation, fmt.Sprintf("Unused %s annotation. No hardcoded value it allows is reported on this line or the next", annotationPrefix))
+		default:
+			continue
+		}
+		if err := runner.EmitIssue(r, message, a.Range); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,99 @@
+package rules
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// baselineRuleName is the name of AwsMetaBaselineRule, which writes the
+// baseline in update mode
+const baselineRuleName = "aws_meta_baseline"
+
+// AwsMetaBaselineRule reports baseline entries that no longer match a
+// finding, or writes the baseline in update mode. It runs after the other
+// rules of the ruleset, so it knows which entries they matched.
+type AwsMetaBaselineRule struct {
+	tflint.DefaultRule
+}
+
+// NewAwsMetaBaselineRule returns a new rule
+func NewAwsMetaBaselineRule() *AwsMetaBaselineRule {
+	return &AwsMetaBaselineRule{}
+}
+
+// Name returns the rule name
+func (r *AwsMetaBaselineRule) Name() string {
+	return baselineRuleName
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *AwsMetaBaselineRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *AwsMetaBaselineRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Link returns the rule reference link
+func (r *AwsMetaBaselineRule) Link() string {
+	return ""
+}
+
+// Check reports the stale baseline entries of the module. The baseline file is
+// not part of the module, so they are reported at the block they name, or at
+// the start of the module when it is gone, with their place in the file in
+// the message. The baseline is only known within a check of the ruleset, so
+// run on its own the rule does nothing.
+func (r *AwsMetaBaselineRule) Check(runner tflint.Runner) error {
+	cr, ok := runner.(*checkRunner)
+	if !ok || cr.baseline == nil {
+		return nil
+	}
+	if cr.baseline.update {
+		return cr.baseline.write()
+	}
+
+	stale := cr.baseline.stale()
+	if len(stale) == 0 {
+		return nil
+	}
+	files, err := cr.moduleFiles()
+	if err != nil {
+		return err
+	}
+
+	known := cr.baseline.baseline
+	for _, i := range stale {
+		entry, rng := known.Entries[i], known.Ranges[i]
+		message := fmt.Sprintf("Stale baseline entry at %s:%d. %s no longer reports '%s' in %s", rng.Filename, rng.Start.Line, entry.Rule, entry.Value, entry.Address)
+		if entry.Attribute != "" {
+			message = fmt.Sprintf("Stale baseline entry at %s:%d. %s no longer reports '%s' in %s.%s", rng.Filename, rng.Start.Line, entry.Rule, entry.Value, entry.Address, entry.Attribute)
+		}
+		if err := runner.EmitIssue(r, codedMessage(codeStaleBaseline, message), staleRange(files, entry)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// staleRange returns where a stale entry is reported: the block it names, or
+// the start of the first file of the module
+func staleRange(files map[string]*hcl.File, entry baselineEntry) hcl.Range {
+	if rng, ok := addressRange(files, entry.Address); ok {
+		return rng
+	}
+
+	names := slices.Sorted(maps.Keys(files))
+	if len(names) == 0 {
+		return hcl.Range{}
+	}
+	start := hcl.Pos{Line: 1, Column: 1}
+	return hcl.Range{Filename: names[0], Start: start, End: start}
+}
@@ -13,6 +13,7 @@ import (
 // across all AWS resources by walking all expressions
 type AwsMetaHardcodedRule struct {
 	tflint.DefaultRule
+	withCatalog
 }
 
 // NewAwsMetaHardcodedRule returns a new rule
@@ -40,116 +41,215 @@ func (r *AwsMetaHardcodedRule) Link() string {
 	return ""
 }
 
-// Check checks for hardcoded regions and partitions in ARN-like string values
 // Check checks for hardcoded regions and partitions in ARN-like string values
 func (r *AwsMetaHardcodedRule) Check(runner tflint.Runner) error {
-	arnRegionPattern := awsmeta.GetARNRegionPattern()
-	arnPartitionPattern := awsmeta.GetPartitionPattern()
-	azPattern := awsmeta.GetAvailabilityZonePattern()
-	regionPattern := awsmeta.GetRegionPattern()
-
-	// Get all source files upfront so we can inspect raw expression text
-	// before making expensive gRPC EvaluateExpr calls
-	files, err := runner.GetFiles()
+	return scanExpressions(runner, r)
+}
+
+func (r *AwsMetaHardcodedRule) detectors() ([]Detector, error) {
+	matcher, err := r.matcher()
 	if err != nil {
-		return err
+		return nil, err
 	}
+	return []Detector{hardcodedRegionDetector{matcher}}, nil
+}
 
-	// Track which expressions we've already checked to avoid duplicates
-	checked := make(map[string]bool)
+// hardcodedRegionDetector finds hardcoded regions and partitions in ARNs, as well
+// as standalone regions and availability zones
+type hardcodedRegionDetector struct {
+	matcher *awsmeta.Matcher
+}
 
-	// Walk all expressions in the Terraform files
-	diags := runner.WalkExpressions(tflint.ExprWalkFunc(func(expr hcl.Expression) hcl.Diagnostics {
-		exprKey := fmt.Sprintf("%s:%d:%d", expr.Range().Filename, expr.Range().Start.Line, expr.Range().Start.Column)
-		if checked[exprKey] {
-			return nil
-		}
-		checked[exprKey] = true
-
-		// Pre-filter: check the raw source text for potential matches before
-		// making the expensive gRPC EvaluateExpr call.
-		exprRange := expr.Range()
-		if file, ok := files[exprRange.Filename]; ok {
-			src := file.Bytes
-			if exprRange.Start.Byte < len(src) && exprRange.End.Byte <= len(src) {
-				sourceText := strings.ToLower(string(src[exprRange.Start.Byte:exprRange.End.Byte]))
-				hasARN := strings.Contains(sourceText, "arn:")
-				hasRegionLike := awsmeta.GetRegionInStringPattern().MatchString(sourceText)
-				if !hasARN && !hasRegionLike {
-					return nil
-				}
+// Candidate pre-filters on the raw source text so that only expressions which
+// may contain an ARN or a region are evaluated
+func (d hardcodedRegionDetector) Candidate(expr hcl.Expression, src string) bool {
+	if strings.Contains(src, "arn:") {
+		return true
+	}
+	_, ok := d.matcher.First(src, awsmeta.HitRegion)
+	return ok
+}
+
+// Detect finds hardcoded regions and partitions in the evaluated value
+func (d hardcodedRegionDetector) Detect(value string) []Match {
+	// Check for hardcoded regions and partitions in every ARN of the value,
+	// such as a list of ARNs joined into one string. Regions are reported
+	// first, as they are the fields fixes start from.
+	if strings.HasPrefix(value, "arn:") {
+		var regions, partitions []Match
+		for _, hit := range d.matcher.Find(value) {
+			if match, ok := arnMatch(hit); ok && match.Kind == kindRegion {
+				regions = append(regions, match)
+			} else if ok {
+				partitions = append(partitions, match)
 			}
 		}
+		return append(regions, partitions...)
+	}
 
-		// Try to evaluate the expression as a string
-		err := runner.EvaluateExpr(expr, func(value string) error {
-			// Check for hardcoded ARN values
-			if strings.HasPrefix(value, "arn:") {
-				// Check for hardcoded region in ARN
-				if matches := arnRegionPattern.FindStringSubmatch(value); len(matches) > 1 {
-					region := matches[1]
-					if err := runner.EmitIssue(
-						r,
-						fmt.Sprintf("Hardcoded AWS region '%s' found in ARN. Consider using data.aws_region.current.name", region),
-						expr.Range(),
-					); err != nil {
-						return err
-					}
-				}
+	// Check for hardcoded availability zone (e.g. "eu-west-2a")
+	if d.matcher.Is(value, awsmeta.HitAvailabilityZone) {
+		return []Match{{
+			Kind:    kindAvailabilityZone,
+			Value:   value,
+			Message: fmt.Sprintf("Hardcoded AWS availability zone '%s' found. Consider using data.aws_availability_zones to dynamically fetch AZs for the current region", value),
+			Region:  d.zoneRegion(value),
+		}}
+	}
 
-				// Check for hardcoded partition in ARN
-				if matches := arnPartitionPattern.FindStringSubmatch(value); len(matches) > 1 {
-					partition := matches[1]
-					if err := runner.EmitIssue(
-						r,
-						fmt.Sprintf("Hardcoded AWS partition '%s' found in ARN. Consider using data.aws_partition.current.partition", partition),
-						expr.Range(),
-					); err != nil {
-						return err
-					}
-				}
+	// Check for hardcoded region as a standalone value (e.g. "eu-west-2")
+	if d.matcher.Is(value, awsmeta.HitRegion) {
+		return []Match{{
+			Kind:    kindRegion,
+			Value:   value,
+			Message: fmt.Sprintf("Hardcoded AWS region '%s' found. Consider using data.aws_region.current.name", value),
+		}}
+	}
+
+	return nil
+}
+
+// zoneRegion returns the region an availability zone starts with
+func (d hardcodedRegionDetector) zoneRegion(zone string) string {
+	hit, _ := d.matcher.First(zone, awsmeta.HitRegion)
+	return hit.Value
+}
 
-				return nil
+// DetectTemplate finds hardcoded values in the literal text of a template
+// whose value is not known. The fields of ARNs are reported wherever the ARNs
+// are, and outside of ARNs, regions and availability zones are reported where
+// they stand apart from the text around them, as in "${var.name}-us-east-1".
+func (d hardcodedRegionDetector) DetectTemplate(text string) []Match {
+	if strings.HasPrefix(text, "arn:") {
+		return d.Detect(text)
+	}
+
+	var matches []Match
+	zoneEnd := -1
+	for _, hit := range d.matcher.Find(text) {
+		if !standsApart(text, hit.Start, hit.End) {
+			continue
+		}
+		if match, ok := arnMatch(hit); ok {
+			matches = append(matches, match)
+			continue
+		}
+		switch {
+		case hit.Kind == awsmeta.HitAvailabilityZone:
+			zoneEnd = hit.End
+			matches = append(matches, Match{
+				Kind:    kindAvailabilityZone,
+				Value:   hit.Value,
+				Message: fmt.Sprintf("Hardcoded AWS availability zone '%s' found. Consider using data.aws_availability_zones to dynamically fetch AZs for the current region", hit.Value),
+				Region:  d.zoneRegion(hit.Value),
+			})
+		case hit.Kind == awsmeta.HitRegion && hit.Start >= zoneEnd:
+			matches = append(matches, Match{
+				Kind:    kindRegion,
+				Value:   hit.Value,
+				Message: fmt.Sprintf("Hardcoded AWS region '%s' found. Consider using data.aws_region.current.name", hit.Value),
+			})
+		}
+	}
+	return matches
+}
+
+// standsApart reports whether text[start:end] is not part of a longer word
+func standsApart(text string, start, end int) bool {
+	return (start == 0 || !isAlphanumericByte(text[start-1])) && (end == len(text) || !isAlphanumericByte(text[end]))
+}
+
+// arnMatch returns the match of a region or partition hit in an ARN
+func arnMatch(hit awsmeta.Hit) (Match, bool) {
+	switch {
+	case hit.Kind == awsmeta.HitRegion && hit.InARN:
+		return Match{
+			Kind:    kindRegion,
+			Value:   hit.Value,
+			Message: fmt.Sprintf("Hardcoded AWS region '%s' found in ARN. Consider using data.aws_region.current.name", hit.Value),
+		}, true
+	case hit.Kind == awsmeta.HitPartition:
+		return Match{
+			Kind:    kindPartition,
+			Value:   hit.Value,
+			Message: fmt.Sprintf("Hardcoded AWS partition '%s' found in ARN. Consider using data.aws_partition.current.partition", hit.Value),
+		}, true
+	}
+	return Match{}, false
+}
+
+// References that replace hardcoded ARN fields when fixing, and the data
+// sources they point to
+var (
+	partitionReference = arnReference{partitionDataSource, "${data.aws_partition.current.partition}"}
+	regionReference    = arnReference{regionDataSource, "${data.aws_region.current.name}"}
+	accountReference   = arnReference{callerIdentityDataSource, "${data.aws_caller_identity.current.account_id}"}
+)
+
+type arnReference struct {
+	dataSource string
+	text       string
+}
+
+// fixes rewrites the hardcoded fields of the ARNs behind the findings into
+// references to the aws_partition, aws_region and aws_caller_identity data
+// sources. Only literal text is rewritten, so quoting, heredocs and existing
+// interpolations are left as they are. Each finding rewrites the fields of its
+// own kind and the account, so the account is rewritten even when TFLint
+// discards the fix of another finding in the same ARN. Rewriting it again is
+// a no-op for the fixer. Data sources missing from the module are added
+// alongside.
+func (r *AwsMetaHardcodedRule) fixes(findings []finding, ctx *fixContext) []func(tflint.Fixer) error {
+	fixes := make([]func(tflint.Fixer) error, len(findings))
+
+	for i, f := range findings {
+		// JSON syntax would need its own escaping rules, so leave it alone
+		if strings.HasSuffix(f.Range.Filename, ".json") || !ctx.fixable(f.Range) {
+			continue
+		}
+
+		var ranges []hcl.Range
+		var references []arnReference
+		replace := func(field *awsmeta.ARNField, reference arnReference) {
+			ranges = append(ranges, subRange(f.Range, f.Source, field.Start, field.End))
+			references = append(references, reference)
+		}
+
+		for _, arn := range awsmeta.FindARNFields(f.Source) {
+			switch {
+			case f.Kind == kindPartition && arn.Partition != nil && f.Source[arn.Partition.Start:arn.Partition.End] == f.Value:
+				replace(arn.Partition, partitionReference)
+			case f.Kind == kindRegion && arn.Region != nil && f.Source[arn.Region.Start:arn.Region.End] == f.Value:
+				replace(arn.Region, regionReference)
+			default:
+				continue
 			}
 
-			// Check for hardcoded availability zone (e.g. "eu-west-2a")
-			if azPattern.MatchString(value) {
-				// Extract the region part (everything except the last character)
-				region := value[:len(value)-1]
-				if err := runner.EmitIssue(
-					r,
-					fmt.Sprintf("Hardcoded AWS availability zone '%s' found. Consider using data.aws_availability_zones to dynamically fetch AZs for the current region", value),
-					expr.Range(),
-				); err != nil {
-					return err
-				}
-				_ = region
-				return nil
+			if arn.Account == nil {
+				continue
 			}
+			account := f.Source[arn.Account.Start:arn.Account.End]
+			if account != "" && awsmeta.IsAccountID(account) && !ctx.allowsAccount(account) {
+				replace(arn.Account, accountReference)
+			}
+		}
 
-			// Check for hardcoded region as a standalone value (e.g. "eu-west-2")
-			if regionPattern.MatchString(value) {
-				if err := runner.EmitIssue(
-					r,
-					fmt.Sprintf("Hardcoded AWS region '%s' found. Consider using data.aws_region.current.name", value),
-					expr.Range(),
-				); err != nil {
+		if len(ranges) == 0 {
+			continue
+		}
+		filename := f.Range.Filename
+		fixes[i] = func(fixer tflint.Fixer) error {
+			for j, rng := range ranges {
+				if err := fixer.ReplaceText(rng, references[j].text); err != nil {
+					return err
+				}
+				if err := ctx.data.ensure(fixer, filename, references[j].dataSource, "current", ""); err != nil {
 					return err
 				}
 			}
-
 			return nil
-		}, nil)
-
-		// Silently ignore evaluation errors
-		_ = err
-
-		return nil
-	}))
-
-	if diags.HasErrors() {
-		return diags
+		}
 	}
 
-	return nil
+	return fixes
 }
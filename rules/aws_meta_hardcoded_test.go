@@ -1,9 +1,12 @@
 package rules
 
 import (
+	"os"
 	"testing"
 
+	"github.com/hashicorp/hcl/v2"
 	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
 )
 
 func Test_AwsMetaHardcodedRule(t *testing.T) {
@@ -26,7 +29,7 @@ resource "aws_iam_role" "test" {
     }]
   })
 }`,
-			ExpectedCount: 4, // 2x because WalkExpressions visits nested expressions
+			ExpectedCount: 2,
 		},
 		{
 			Name: "lambda permission with hardcoded source_arn",
@@ -34,7 +37,7 @@ resource "aws_iam_role" "test" {
 resource "aws_lambda_permission" "test" {
   source_arn = "arn:aws:s3:eu-west-1:123456789012:bucket/my-bucket"
 }`,
-			ExpectedCount: 4,
+			ExpectedCount: 2,
 		},
 		{
 			Name: "lambda event source mapping with hardcoded event_source_arn",
@@ -42,7 +45,7 @@ resource "aws_lambda_permission" "test" {
 resource "aws_lambda_event_source_mapping" "test" {
   event_source_arn = "arn:aws:dynamodb:us-east-1:123456789012:table/my-table"
 }`,
-			ExpectedCount: 4,
+			ExpectedCount: 2,
 		},
 		{
 			Name: "sns subscription with hardcoded topic_arn",
@@ -50,7 +53,7 @@ resource "aws_lambda_event_source_mapping" "test" {
 resource "aws_sns_topic_subscription" "test" {
   topic_arn = "arn:aws:sns:us-west-2:123456789012:my-topic"
 }`,
-			ExpectedCount: 4,
+			ExpectedCount: 2,
 		},
 		{
 			Name: "cloudwatch event target with hardcoded arn",
@@ -58,7 +61,7 @@ resource "aws_sns_topic_subscription" "test" {
 resource "aws_cloudwatch_event_target" "test" {
   arn = "arn:aws:lambda:us-west-2:123456789012:function:my-function"
 }`,
-			ExpectedCount: 4,
+			ExpectedCount: 2,
 		},
 		{
 			Name: "cloudwatch log subscription filter with hardcoded destination_arn",
@@ -66,7 +69,7 @@ resource "aws_cloudwatch_event_target" "test" {
 resource "aws_cloudwatch_log_subscription_filter" "test" {
   destination_arn = "arn:aws:lambda:eu-west-1:123456789012:function:my-function"
 }`,
-			ExpectedCount: 4,
+			ExpectedCount: 2,
 		},
 		{
 			Name: "api gateway integration with hardcoded uri",
@@ -74,7 +77,7 @@ resource "aws_cloudwatch_log_subscription_filter" "test" {
 resource "aws_api_gateway_integration" "test" {
   uri = "arn:aws:apigateway:us-east-1:lambda:path/2015-03-31/functions/arn:aws:lambda:us-east-1:123456789012:function:my-function/invocations"
 }`,
-			ExpectedCount: 4, // Only the lambda ARN is detected, not the apigateway ARN format
+			ExpectedCount: 4, // The apigateway ARN and the lambda ARN it holds
 		},
 		{
 			Name: "kms grant with hardcoded key_id",
@@ -82,7 +85,7 @@ resource "aws_api_gateway_integration" "test" {
 resource "aws_kms_grant" "test" {
   key_id = "arn:aws:kms:us-west-2:123456789012:key/12345678-1234-1234-1234-123456789012"
 }`,
-			ExpectedCount: 4,
+			ExpectedCount: 2,
 		},
 		{
 			Name: "kms alias with hardcoded target_key_id",
@@ -90,7 +93,7 @@ resource "aws_kms_grant" "test" {
 resource "aws_kms_alias" "test" {
   target_key_id = "arn:aws:kms:eu-west-1:123456789012:key/12345678-1234-1234-1234-123456789012"
 }`,
-			ExpectedCount: 4,
+			ExpectedCount: 2,
 		},
 		{
 			Name: "secretsmanager rotation with hardcoded rotation_lambda_arn",
@@ -98,7 +101,7 @@ resource "aws_kms_alias" "test" {
 resource "aws_secretsmanager_secret_rotation" "test" {
   rotation_lambda_arn = "arn:aws:lambda:us-east-1:123456789012:function:my-rotation-function"
 }`,
-			ExpectedCount: 4,
+			ExpectedCount: 2,
 		},
 		{
 			Name: "db instance with hardcoded replicate_source_db",
@@ -106,7 +109,7 @@ resource "aws_secretsmanager_secret_rotation" "test" {
 resource "aws_db_instance" "test" {
   replicate_source_db = "arn:aws:rds:us-east-1:123456789012:db:my-source-db"
 }`,
-			ExpectedCount: 4,
+			ExpectedCount: 2,
 		},
 		{
 			Name: "db event subscription with hardcoded sns_topic",
@@ -114,7 +117,7 @@ resource "aws_db_instance" "test" {
 resource "aws_db_event_subscription" "test" {
   sns_topic = "arn:aws:sns:eu-west-1:123456789012:my-topic"
 }`,
-			ExpectedCount: 4,
+			ExpectedCount: 2,
 		},
 		{
 			Name: "multiple resources with different partitions",
@@ -126,7 +129,7 @@ resource "aws_lambda_permission" "test1" {
 resource "aws_sns_topic_subscription" "test2" {
   topic_arn = "arn:aws-cn:sns:cn-north-1:123456789012:my-topic"
 }`,
-			ExpectedCount: 8,
+			ExpectedCount: 4,
 		},
 		{
 			Name: "resource with dynamic ARN using data sources",
@@ -161,7 +164,7 @@ module "vpc" {
     }
   }
 }`,
-			ExpectedCount: 6, // 3 AZs x 2 (WalkExpressions visits nested)
+			ExpectedCount: 3,
 		},
 		{
 			Name: "hardcoded availability zone in resource",
@@ -169,7 +172,7 @@ module "vpc" {
 resource "aws_instance" "test" {
   availability_zone = "us-east-1a"
 }`,
-			ExpectedCount: 2,
+			ExpectedCount: 1,
 		},
 		{
 			Name: "hardcoded region as standalone value",
@@ -180,7 +183,23 @@ resource "aws_instance" "test" {
     Region = "us-east-1"
   }
 }`,
-			ExpectedCount: 2,
+			ExpectedCount: 1,
+		},
+		{
+			Name: "hardcoded region in a template with unknown parts",
+			Content: `
+variable "name" {}
+variable "zone" {}
+
+resource "aws_s3_bucket" "test" {
+  bucket = "${var.name}-eu-west-2"
+  tags = {
+    Zone     = "${var.name}/us-east-1a"
+    Name     = "${var.name}-service1"
+    Adjacent = "${var.zone}eu-west-2b"
+  }
+}`,
+			ExpectedCount: 3,
 		},
 		{
 			Name: "dynamic availability zones using data source",
@@ -216,3 +235,483 @@ module "vpc" {
 		})
 	}
 }
+
+func Test_AwsMetaHardcodedRule_AllowedValues(t *testing.T) {
+	tests := []struct {
+		Name          string
+		Config        string
+		Content       string
+		ExpectedCount int
+	}{
+		{
+			Name: "allowed region",
+			Config: `
+rule "aws_meta_hardcoded" {
+  enabled         = true
+  allowed_regions = ["us-east-1"]
+}`,
+			Content: `
+resource "aws_cloudfront_distribution" "test" {
+  viewer_certificate {
+    acm_certificate_arn = "arn:aws:acm:us-east-1:123456789012:certificate/abc"
+  }
+}`,
+			ExpectedCount: 1,
+		},
+		{
+			Name: "allowed region covers its availability zones",
+			Config: `
+rule "aws_meta_hardcoded" {
+  enabled         = true
+  allowed_regions = ["eu-west-2"]
+}`,
+			Content: `
+resource "aws_instance" "test" {
+  availability_zone = "eu-west-2a"
+}
+
+resource "aws_instance" "other" {
+  availability_zone = "us-east-1a"
+}`,
+			ExpectedCount: 1,
+		},
+		{
+			Name: "allowed partition",
+			Config: `
+rule "aws_meta_hardcoded" {
+  enabled            = true
+  allowed_partitions = ["aws"]
+}`,
+			Content: `
+resource "aws_sns_topic_subscription" "test" {
+  topic_arn = "arn:aws:sns:us-west-2:123456789012:my-topic"
+}`,
+			ExpectedCount: 1,
+		},
+	}
+
+	rule := NewAwsMetaHardcodedRule()
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			runner := helper.TestRunner(t, map[string]string{"main.tf": test.Content, ".tflint.hcl": test.Config})
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			if len(runner.Issues) != test.ExpectedCount {
+				t.Errorf("Expected %d issues, got %d", test.ExpectedCount, len(runner.Issues))
+				for i, issue := range runner.Issues {
+					t.Logf("Issue %d: %s", i+1, issue.Message)
+				}
+			}
+		})
+	}
+}
+
+func Test_AwsMetaHardcodedRule_MultipleARNs(t *testing.T) {
+	content := `
+resource "aws_sns_topic_subscription" "test" {
+  endpoint = "arn:aws:sqs:us-east-1:123456789012:a,arn:aws-cn:sqs:cn-north-1:123456789012:b,arn:aws:sqs:us-east-1:123456789012:c"
+}`
+
+	rule := NewAwsMetaHardcodedRule()
+	runner := helper.TestRunner(t, map[string]string{"main.tf": content})
+
+	if err := rule.Check(runner); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	helper.AssertIssues(t, helper.Issues{
+		{
+			Rule:    rule,
+			Message: "[AWSMETA002 kind=partition value=aws address=aws_sns_topic_subscription.test replacement=data.aws_partition.current.partition] Hardcoded AWS partition 'aws' found in ARN. Consider using data.aws_partition.current.partition",
+			Range: hcl.Range{
+				Filename: "main.tf",
+				Start:    hcl.Pos{Line: 3, Column: 19},
+				End:      hcl.Pos{Line: 3, Column: 22},
+			},
+		},
+		{
+			Rule:    rule,
+			Message: "[AWSMETA001 kind=region value=us-east-1 address=aws_sns_topic_subscription.test replacement=data.aws_region.current.name] Hardcoded AWS region 'us-east-1' found in ARN. Consider using data.aws_region.current.name",
+			Range: hcl.Range{
+				Filename: "main.tf",
+				Start:    hcl.Pos{Line: 3, Column: 27},
+				End:      hcl.Pos{Line: 3, Column: 36},
+			},
+		},
+		{
+			Rule:    rule,
+			Message: "[AWSMETA002 kind=partition value=aws-cn address=aws_sns_topic_subscription.test replacement=data.aws_partition.current.partition] Hardcoded AWS partition 'aws-cn' found in ARN. Consider using data.aws_partition.current.partition",
+			Range: hcl.Range{
+				Filename: "main.tf",
+				Start:    hcl.Pos{Line: 3, Column: 56},
+				End:      hcl.Pos{Line: 3, Column: 62},
+			},
+		},
+		{
+			Rule:    rule,
+			Message: "[AWSMETA001 kind=region value=cn-north-1 address=aws_sns_topic_subscription.test replacement=data.aws_region.current.name] Hardcoded AWS region 'cn-north-1' found in ARN. Consider using data.aws_region.current.name",
+			Range: hcl.Range{
+				Filename: "main.tf",
+				Start:    hcl.Pos{Line: 3, Column: 67},
+				End:      hcl.Pos{Line: 3, Column: 77},
+			},
+		},
+		{
+			Rule:    rule,
+			Message: "[AWSMETA002 kind=partition value=aws address=aws_sns_topic_subscription.test replacement=data.aws_partition.current.partition] Hardcoded AWS partition 'aws' found in ARN. Consider using data.aws_partition.current.partition",
+			Range: hcl.Range{
+				Filename: "main.tf",
+				Start:    hcl.Pos{Line: 3, Column: 97},
+				End:      hcl.Pos{Line: 3, Column: 100},
+			},
+		},
+		{
+			Rule:    rule,
+			Message: "[AWSMETA001 kind=region value=us-east-1 address=aws_sns_topic_subscription.test replacement=data.aws_region.current.name] Hardcoded AWS region 'us-east-1' found in ARN. Consider using data.aws_region.current.name",
+			Range: hcl.Range{
+				Filename: "main.tf",
+				Start:    hcl.Pos{Line: 3, Column: 105},
+				End:      hcl.Pos{Line: 3, Column: 114},
+			},
+		},
+	}, runner.Issues)
+}
+
+func Test_AwsMetaHardcodedRule_ExternalFile(t *testing.T) {
+	t.Chdir(t.TempDir())
+	definitions := `[
+  {
+    "name": "app",
+    "image": "${image}",
+    "environment": [
+      {"name": "AWS_REGION", "value": "eu-west-2"},
+      {"name": "QUEUE_ARN", "value": "arn:aws:sqs:eu-west-2:${account_id}:jobs"}
+    ]
+  }
+]
+`
+	if err := os.WriteFile("containers.json.tpl", []byte(definitions), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	// Plain text is scanned line by line
+	if err := os.WriteFile("user_data.sh", []byte("#!/bin/sh\nexport AWS_REGION=us-east-1\necho \"zone: us-east-1b\"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	content := `
+resource "aws_ecs_task_definition" "test" {
+  container_definitions = templatefile("containers.json.tpl", {})
+}
+
+resource "aws_instance" "test" {
+  user_data = file("user_data.sh")
+}`
+
+	rule := NewAwsMetaHardcodedRule()
+	runner := helper.TestRunner(t, map[string]string{"main.tf": content})
+
+	if err := rule.Check(runner); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	call := hcl.Range{
+		Filename: "main.tf",
+		Start:    hcl.Pos{Line: 3, Column: 27},
+		End:      hcl.Pos{Line: 3, Column: 66},
+	}
+	userData := hcl.Range{
+		Filename: "main.tf",
+		Start:    hcl.Pos{Line: 7, Column: 15},
+		End:      hcl.Pos{Line: 7, Column: 35},
+	}
+	helper.AssertIssues(t, helper.Issues{
+		{
+			Rule:    rule,
+			Message: "[AWSMETA001 kind=region value=eu-west-2 address=aws_ecs_task_definition.test replacement=data.aws_region.current.name] Hardcoded AWS region 'eu-west-2' found. Consider using data.aws_region.current.name (in containers.json.tpl line 6)",
+			Range:   call,
+		},
+		{
+			Rule:    rule,
+			Message: "[AWSMETA001 kind=region value=eu-west-2 address=aws_ecs_task_definition.test replacement=data.aws_region.current.name] Hardcoded AWS region 'eu-west-2' found in ARN. Consider using data.aws_region.current.name (in containers.json.tpl line 7)",
+			Range:   call,
+		},
+		{
+			Rule:    rule,
+			Message: "[AWSMETA002 kind=partition value=aws address=aws_ecs_task_definition.test replacement=data.aws_partition.current.partition] Hardcoded AWS partition 'aws' found in ARN. Consider using data.aws_partition.current.partition (in containers.json.tpl line 7)",
+			Range:   call,
+		},
+		{
+			Rule:    rule,
+			Message: "[AWSMETA001 kind=region value=us-east-1 address=aws_instance.test replacement=data.aws_region.current.name] Hardcoded AWS region 'us-east-1' found. Consider using data.aws_region.current.name (in user_data.sh line 2)",
+			Range:   userData,
+		},
+		{
+			Rule:    rule,
+			Message: "[AWSMETA003 kind=availability_zone value=us-east-1b address=aws_instance.test replacement=data.aws_availability_zones.available.names] Hardcoded AWS availability zone 'us-east-1b' found. Consider using data.aws_availability_zones to dynamically fetch AZs for the current region (in user_data.sh line 3)",
+			Range:   userData,
+		},
+	}, runner.Issues)
+}
+
+func Test_AwsMetaHardcodedRule_Fix(t *testing.T) {
+	// Data sources that the fixed references point to
+	dataBlocks := `
+data "aws_partition" "current" {}
+data "aws_region" "current" {}
+data "aws_caller_identity" "current" {}
+`
+
+	tests := []struct {
+		Name         string
+		Config       string
+		Content      string
+		Data         string
+		Expected     string
+		ExpectedData string
+
+		// DiscardFirst discards the fix of the first finding
+		DiscardFirst bool
+	}{
+		{
+			Name: "quoted ARN",
+			Content: `
+resource "aws_lambda_permission" "test" {
+  source_arn = "arn:aws:s3:eu-west-1:123456789012:bucket/my-bucket"
+}`,
+			Data: dataBlocks,
+			Expected: `
+resource "aws_lambda_permission" "test" {
+  source_arn = "arn:${data.aws_partition.current.partition}:s3:${data.aws_region.current.name}:${data.aws_caller_identity.current.account_id}:bucket/my-bucket"
+}`,
+		},
+		{
+			Name: "account is fixed when the fix of another finding is discarded",
+			Content: `
+resource "aws_lambda_permission" "test" {
+  source_arn = "arn:aws:s3:eu-west-1:123456789012:bucket/my-bucket"
+}`,
+			Data:         dataBlocks,
+			DiscardFirst: true,
+			Expected: `
+resource "aws_lambda_permission" "test" {
+  source_arn = "arn:${data.aws_partition.current.partition}:s3:eu-west-1:${data.aws_caller_identity.current.account_id}:bucket/my-bucket"
+}`,
+		},
+		{
+			Name: "ARN without region or account",
+			Content: `
+resource "aws_iam_role" "test" {
+  assume_role_policy = jsonencode({
+    Statement = [{
+      Resource = "arn:aws:s3:::my-bucket/*"
+    }]
+  })
+}`,
+			Data: dataBlocks,
+			Expected: `
+resource "aws_iam_role" "test" {
+  assume_role_policy = jsonencode({
+    Statement = [{
+      Resource = "arn:${data.aws_partition.current.partition}:s3:::my-bucket/*"
+    }]
+  })
+}`,
+		},
+		{
+			Name: "heredoc",
+			Content: `
+resource "aws_sns_topic_subscription" "test" {
+  endpoint = <<EOF
+arn:aws:sqs:us-east-1:123456789012:queue
+EOF
+}`,
+			Data: dataBlocks,
+			Expected: `
+resource "aws_sns_topic_subscription" "test" {
+  endpoint = <<EOF
+arn:${data.aws_partition.current.partition}:sqs:${data.aws_region.current.name}:${data.aws_caller_identity.current.account_id}:queue
+EOF
+}`,
+		},
+		{
+			Name: "every ARN in a string is fixed",
+			Content: `
+resource "aws_sns_topic_subscription" "test" {
+  endpoint = "arn:aws:sqs:us-east-1:123456789012:a,arn:aws:sqs:eu-west-1:123456789012:b"
+}`,
+			Data: dataBlocks,
+			Expected: `
+resource "aws_sns_topic_subscription" "test" {
+  endpoint = "arn:${data.aws_partition.current.partition}:sqs:${data.aws_region.current.name}:${data.aws_caller_identity.current.account_id}:a,arn:${data.aws_partition.current.partition}:sqs:${data.aws_region.current.name}:${data.aws_caller_identity.current.account_id}:b"
+}`,
+		},
+		{
+			Name: "existing interpolations are kept",
+			Content: `
+variable "account" {
+  default = "123456789012"
+}
+
+resource "aws_lambda_permission" "test" {
+  source_arn = "arn:aws:s3:eu-west-1:${var.account}:bucket/my-bucket"
+}`,
+			Data: dataBlocks,
+			Expected: `
+variable "account" {
+  default = "123456789012"
+}
+
+resource "aws_lambda_permission" "test" {
+  source_arn = "arn:${data.aws_partition.current.partition}:s3:${data.aws_region.current.name}:${var.account}:bucket/my-bucket"
+}`,
+		},
+		{
+			Name: "variable defaults are not fixed",
+			Content: `
+variable "topic" {
+  default = "arn:aws:sns:us-west-2:123456789012:my-topic"
+}
+
+resource "aws_lambda_permission" "test" {
+  source_arn = "arn:aws:s3:eu-west-1::bucket/my-bucket"
+}`,
+			Data: dataBlocks,
+			Expected: `
+variable "topic" {
+  default = "arn:aws:sns:us-west-2:123456789012:my-topic"
+}
+
+resource "aws_lambda_permission" "test" {
+  source_arn = "arn:${data.aws_partition.current.partition}:s3:${data.aws_region.current.name}::bucket/my-bucket"
+}`,
+		},
+		{
+			Name: "allowed values are kept",
+			Config: `
+rule "aws_meta_hardcoded" {
+  enabled             = true
+  allowed_regions     = ["us-east-1"]
+  allowed_account_ids = ["123456789012"]
+}`,
+			Content: `
+resource "aws_cloudfront_distribution" "test" {
+  viewer_certificate {
+    acm_certificate_arn = "arn:aws:acm:us-east-1:123456789012:certificate/abc"
+  }
+}`,
+			Data: dataBlocks,
+			Expected: `
+resource "aws_cloudfront_distribution" "test" {
+  viewer_certificate {
+    acm_certificate_arn = "arn:${data.aws_partition.current.partition}:acm:us-east-1:123456789012:certificate/abc"
+  }
+}`,
+		},
+		{
+			Name: "accounts allowed by aws_hardcoded_ids are kept",
+			Config: `
+rule "aws_hardcoded_ids" {
+  enabled             = true
+  allowed_account_ids = ["123456789012"]
+}`,
+			Content: `
+resource "aws_lambda_permission" "test" {
+  source_arn = "arn:aws:s3:eu-west-1:123456789012:bucket/my-bucket"
+}`,
+			Data: dataBlocks,
+			Expected: `
+resource "aws_lambda_permission" "test" {
+  source_arn = "arn:${data.aws_partition.current.partition}:s3:${data.aws_region.current.name}:123456789012:bucket/my-bucket"
+}`,
+		},
+		{
+			Name: "missing data blocks are added to data.tf",
+			Content: `
+resource "aws_lambda_permission" "test" {
+  source_arn = "arn:aws:s3:eu-west-1::bucket/my-bucket"
+}`,
+			Data: `data "aws_region" "current" {}
+`,
+			Expected: `
+resource "aws_lambda_permission" "test" {
+  source_arn = "arn:${data.aws_partition.current.partition}:s3:${data.aws_region.current.name}::bucket/my-bucket"
+}`,
+			ExpectedData: `data "aws_region" "current" {}
+
+data "aws_partition" "current" {}
+`,
+		},
+		{
+			Name: "missing data blocks are added to the fixed file without a data file",
+			Content: `
+resource "aws_lambda_permission" "test" {
+  source_arn = "arn:aws:s3:eu-west-1:123456789012:bucket/my-bucket"
+}
+`,
+			Expected: `
+resource "aws_lambda_permission" "test" {
+  source_arn = "arn:${data.aws_partition.current.partition}:s3:${data.aws_region.current.name}:${data.aws_caller_identity.current.account_id}:bucket/my-bucket"
+}
+
+data "aws_region" "current" {}
+
+data "aws_caller_identity" "current" {}
+
+data "aws_partition" "current" {}
+`,
+		},
+		{
+			Name: "data blocks are added when the fix of the first finding is discarded",
+			Content: `
+resource "aws_lambda_permission" "test" {
+  source_arn = "arn:aws:s3:eu-west-1:123456789012:bucket/my-bucket"
+}
+`,
+			DiscardFirst: true,
+			Expected: `
+resource "aws_lambda_permission" "test" {
+  source_arn = "arn:${data.aws_partition.current.partition}:s3:eu-west-1:${data.aws_caller_identity.current.account_id}:bucket/my-bucket"
+}
+
+data "aws_partition" "current" {}
+
+data "aws_caller_identity" "current" {}
+`,
+		},
+	}
+
+	rule := NewAwsMetaHardcodedRule()
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			files := map[string]string{"main.tf": test.Content}
+			if test.Data != "" {
+				files["data.tf"] = test.Data
+			}
+			if test.Config != "" {
+				files[".tflint.hcl"] = test.Config
+			}
+			runner := helper.TestRunner(t, files)
+
+			var check tflint.Runner = runner
+			if test.DiscardFirst {
+				check = &discardingRunner{Runner: runner}
+			}
+			if err := rule.Check(check); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			changes := runner.Changes()
+			if got := string(changes["main.tf"]); got != test.Expected {
+				t.Errorf("Expected fixed content:\n%s\ngot:\n%s", test.Expected, got)
+			}
+			if got := string(changes["data.tf"]); got != test.ExpectedData {
+				t.Errorf("Expected fixed data.tf:\n%s\ngot:\n%s", test.ExpectedData, got)
+			}
+		})
+	}
+}
@@ -13,6 +13,7 @@ import (
 // AwsProviderHardcodedRegionRule checks for hardcoded AWS regions in provider configuration
 type AwsProviderHardcodedRegionRule struct {
 	tflint.DefaultRule
+	withCatalog
 }
 
 // NewAwsProviderHardcodedRegionRule returns a new rule
@@ -42,8 +43,10 @@ func (r *AwsProviderHardcodedRegionRule) Link() string {
 
 // Check checks for hardcoded AWS regions in provider configuration
 func (r *AwsProviderHardcodedRegionRule) Check(runner tflint.Runner) error {
-	regionPattern := awsmeta.GetRegionPattern()
-	arnRegionPattern := awsmeta.GetARNRegionPattern()
+	matcher, err := r.matcher()
+	if err != nil {
+		return err
+	}
 
 	files, err := runner.GetFiles()
 	if err != nil {
@@ -69,6 +72,11 @@ func (r *AwsProviderHardcodedRegionRule) Check(runner tflint.Runner) error {
 		return err
 	}
 
+	config, err := decodeRuleConfig(runner, r)
+	if err != nil {
+		return err
+	}
+
 	for _, provider := range providers.Blocks {
 		if attr, exists := provider.Body.Attributes["region"]; exists {
 			// Skip if the expression is not a literal (e.g. var.region, local.region)
@@ -76,16 +84,17 @@ func (r *AwsProviderHardcodedRegionRule) Check(runner tflint.Runner) error {
 				continue
 			}
 
-			err := runner.EvaluateExpr(attr.Expr, func(region string) error {
-				if regionPattern.MatchString(region) {
-					return runner.EmitIssue(
-						r,
-						fmt.Sprintf("Hardcoded AWS region '%s' in provider configuration. Consider using variables or environment variables for better flexibility", region),
-						attr.Expr.Range(),
-					)
+			err := evaluateString(runner, attr.Expr, func(region string) error {
+				if matcher.Is(region, awsmeta.HitRegion) && !config.allows(kindRegion, region) {
+					return emitIssue(runner, r, Match{
+						Kind:        kindRegion,
+						Value:       region,
+						Message:     fmt.Sprintf("Hardcoded AWS region '%s' in provider configuration. Consider using variables or environment variables for better flexibility", region),
+						Replacement: "var.region",
+					}, newValueLocator(files, attr.Expr).next(kindRegion, region))
 				}
 				return nil
-			}, nil)
+			})
 			if err != nil && !strings.Contains(err.Error(), "cannot convert") {
 				return err
 			}
@@ -98,17 +107,17 @@ func (r *AwsProviderHardcodedRegionRule) Check(runner tflint.Runner) error {
 						continue
 					}
 
-					err := runner.EvaluateExpr(attr.Expr, func(roleArn string) error {
-						if matches := arnRegionPattern.FindStringSubmatch(roleArn); len(matches) > 1 {
-							region := matches[1]
-							return runner.EmitIssue(
-								r,
-								fmt.Sprintf("Hardcoded AWS region '%s' found in assume_role ARN. Consider using variables or data.aws_region.current.name", region),
-								attr.Expr.Range(),
-							)
+					err := evaluateString(runner, attr.Expr, func(roleArn string) error {
+						if hit, ok := matcher.First(roleArn, awsmeta.HitRegion); ok && hit.InARN && !config.allows(kindRegion, hit.Value) {
+							region := hit.Value
+							return emitIssue(runner, r, Match{
+								Kind:    kindRegion,
+								Value:   region,
+								Message: fmt.Sprintf("Hardcoded AWS region '%s' found in assume_role ARN. Consider using variables or data.aws_region.current.name", region),
+							}, newValueLocator(files, attr.Expr).next(kindRegion, region))
 						}
 						return nil
-					}, nil)
+					})
 					if err != nil && !strings.Contains(err.Error(), "cannot convert") {
 						return err
 					}
@@ -122,15 +131,13 @@ func (r *AwsProviderHardcodedRegionRule) Check(runner tflint.Runner) error {
 
 // isReference checks if the expression source text contains variable, local, or data references
 func isReference(files map[string]*hcl.File, exprRange hcl.Range) bool {
-	if file, ok := files[exprRange.Filename]; ok {
-		src := file.Bytes
-		if exprRange.Start.Byte < len(src) && exprRange.End.Byte <= len(src) {
-			sourceText := string(src[exprRange.Start.Byte:exprRange.End.Byte])
-			return strings.Contains(sourceText, "var.") ||
-				strings.Contains(sourceText, "local.") ||
-				strings.Contains(sourceText, "data.") ||
-				strings.Contains(sourceText, "module.")
-		}
+	sourceText, ok := sourceText(files, exprRange)
+	if !ok {
+		return false
 	}
-	return false
+
+	return strings.Contains(sourceText, "var.") ||
+		strings.Contains(sourceText, "local.") ||
+		strings.Contains(sourceText, "data.") ||
+		strings.Contains(sourceText, "module.")
 }
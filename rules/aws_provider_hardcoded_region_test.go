@@ -130,3 +130,50 @@ provider "aws" {
 		})
 	}
 }
+
+func Test_AwsProviderHardcodedRegionRule_AllowedValues(t *testing.T) {
+	tests := []struct {
+		Name          string
+		Config        string
+		Content       string
+		ExpectedCount int
+	}{
+		{
+			Name: "allowed region",
+			Config: `
+rule "aws_provider_hardcoded_region" {
+  enabled         = true
+  allowed_regions = ["us-east-1"]
+}`,
+			Content: `
+provider "aws" {
+  alias  = "us_east_1"
+  region = "us-east-1"
+}
+
+provider "aws" {
+  region = "eu-west-1"
+}`,
+			ExpectedCount: 1,
+		},
+	}
+
+	rule := NewAwsProviderHardcodedRegionRule()
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			runner := helper.TestRunner(t, map[string]string{"main.tf": test.Content, ".tflint.hcl": test.Config})
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			if len(runner.Issues) != test.ExpectedCount {
+				t.Errorf("Expected %d issues, got %d", test.ExpectedCount, len(runner.Issues))
+				for i, issue := range runner.Issues {
+					t.Logf("Issue %d: %s", i+1, issue.Message)
+				}
+			}
+		})
+	}
+}
@@ -42,82 +42,84 @@ func (r *AwsServicePrincipalDNSSuffixRule) Link() string {
 // Pattern to match service names with dns_suffix interpolation
 var dnsSuffixPattern = regexp.MustCompile(`([a-z0-9\-]+)\.\$\{[^}]*\.dns_suffix\}`)
 
+// Pattern to match a quoted service name with dns_suffix interpolation in raw source text
+var dnsSuffixSourcePattern = regexp.MustCompile(`"([a-z0-9\-]+)\.\$\{[^}]*\.dns_suffix\}"`)
+
 // Check checks for use of dns_suffix in service principals
 func (r *AwsServicePrincipalDNSSuffixRule) Check(runner tflint.Runner) error {
-	files, err := runner.GetFiles()
-	if err != nil {
-		return err
-	}
+	return scanExpressions(runner, r)
+}
 
-	checked := make(map[string]bool)
+func (r *AwsServicePrincipalDNSSuffixRule) detectors() ([]Detector, error) {
+	return []Detector{dnsSuffixDetector{}}, nil
+}
 
-	diags := runner.WalkExpressions(tflint.ExprWalkFunc(func(expr hcl.Expression) hcl.Diagnostics {
-		exprKey := fmt.Sprintf("%s:%d:%d", expr.Range().Filename, expr.Range().Start.Line, expr.Range().Start.Column)
-		if checked[exprKey] {
-			return nil
-		}
-		checked[exprKey] = true
-
-		// Pre-filter: check raw source for "dns_suffix" before making gRPC call
-		exprRange := expr.Range()
-		var sourceText string
-		if file, ok := files[exprRange.Filename]; ok {
-			src := file.Bytes
-			if exprRange.Start.Byte < len(src) && exprRange.End.Byte <= len(src) {
-				sourceText = string(src[exprRange.Start.Byte:exprRange.End.Byte])
-				if !strings.Contains(sourceText, "dns_suffix") {
-					return nil
-				}
-			}
-		}
+// dnsSuffixDetector finds service principals built from dns_suffix interpolation
+type dnsSuffixDetector struct{}
 
-		// Skip pure variable/attribute references (e.g. data.aws_partition.current.dns_suffix)
-		// These contain "dns_suffix" in their source but aren't hardcoded strings
-		if _, diags := hcl.AbsTraversalForExpr(expr); !diags.HasErrors() {
-			return nil
-		}
+// Candidate pre-filters on the raw source text for "dns_suffix"
+func (d dnsSuffixDetector) Candidate(expr hcl.Expression, src string) bool {
+	if !strings.Contains(src, "dns_suffix") {
+		return false
+	}
 
-		// Try to evaluate the expression as a string
-		err := runner.EvaluateExpr(expr, func(value string) error {
-			if strings.Contains(value, "dns_suffix") {
-				if matches := dnsSuffixPattern.FindStringSubmatch(value); len(matches) > 1 {
-					serviceName := matches[1]
-					if err := runner.EmitIssue(
-						r,
-						fmt.Sprintf("Service principal uses dns_suffix. Consider using data.aws_service_principal.%s.name instead for better maintainability", strings.ReplaceAll(serviceName, "-", "_")),
-						expr.Range(),
-					); err != nil {
-						return err
-					}
-				}
-			}
-			return nil
-		}, nil)
-
-		// If evaluation failed, check the raw source text directly
-		if err != nil && strings.Contains(sourceText, "dns_suffix") {
-			if matches := regexp.MustCompile(`"([a-z0-9\-]+)\.\$\{[^}]*\.dns_suffix\}"`).FindStringSubmatch(sourceText); len(matches) > 1 {
-				serviceName := matches[1]
-				_ = runner.EmitIssue(
-					r,
-					fmt.Sprintf("Service principal uses dns_suffix. Consider using data.aws_service_principal.%s.name instead for better maintainability", strings.ReplaceAll(serviceName, "-", "_")),
-					expr.Range(),
-				)
-			} else {
-				_ = runner.EmitIssue(
-					r,
-					"Service principal uses dns_suffix. Consider using data.aws_service_principal data source instead for better maintainability",
-					expr.Range(),
-				)
-			}
-		}
+	// Skip pure variable/attribute references (e.g. data.aws_partition.current.dns_suffix)
+	// These contain "dns_suffix" in their source but aren't hardcoded strings
+	_, diags := hcl.AbsTraversalForExpr(expr)
+	return diags.HasErrors()
+}
 
+// Detect finds dns_suffix interpolation in the evaluated value
+func (d dnsSuffixDetector) Detect(value string) []Match {
+	if !strings.Contains(value, "dns_suffix") {
 		return nil
-	}))
+	}
 
-	if diags.HasErrors() {
-		return diags
+	if m := dnsSuffixPattern.FindStringSubmatch(value); len(m) > 1 {
+		return []Match{dnsSuffixMatch(m[1])}
 	}
 
 	return nil
 }
+
+// DetectUnevaluated checks the raw source text directly when evaluation failed
+func (d dnsSuffixDetector) DetectUnevaluated(src string) []Match {
+	if m := dnsSuffixSourcePattern.FindStringSubmatch(src); len(m) > 1 {
+		return []Match{dnsSuffixMatch(m[1])}
+	}
+
+	return []Match{{
+		Kind:    kindDNSSuffix,
+		Message: "Service principal uses dns_suffix. Consider using data.aws_service_principal data source instead for better maintainability",
+	}}
+}
+
+func dnsSuffixMatch(serviceName string) Match {
+	replacement := fmt.Sprintf("data.aws_service_principal.%s.name", strings.ReplaceAll(serviceName, "-", "_"))
+	return Match{
+		Kind:        kindDNSSuffix,
+		Value:       serviceName,
+		Message:     fmt.Sprintf("Service principal uses dns_suffix. Consider using %s instead for better maintainability", replacement),
+		Replacement: replacement,
+	}
+}
+
+// fixes replaces quoted "<service>.${...dns_suffix}" templates with a reference
+// to the aws_service_principal data source
+func (r *AwsServicePrincipalDNSSuffixRule) fixes(findings []finding, ctx *fixContext) []func(tflint.Fixer) error {
+	fixes := make([]func(tflint.Fixer) error, len(findings))
+
+	for i, f := range findings {
+		if strings.HasSuffix(f.Range.Filename, ".json") || !ctx.fixable(f.Range) {
+			continue
+		}
+
+		m := dnsSuffixSourcePattern.FindStringSubmatchIndex(f.Source)
+		if m == nil || m[0] != 0 || m[1] != len(f.Source) {
+			continue
+		}
+		fixes[i] = servicePrincipalFix(ctx, f.Range, f.Source[m[2]:m[3]])
+	}
+
+	return fixes
+}
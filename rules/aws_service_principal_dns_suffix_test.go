@@ -26,7 +26,7 @@ resource "aws_iam_role" "test" {
     }]
   })
 }`,
-			ExpectedCount: 6,
+			ExpectedCount: 1,
 		},
 		{
 			Name: "using dns_suffix with multiple services",
@@ -46,7 +46,7 @@ resource "aws_iam_role" "test" {
     }]
   })
 }`,
-			ExpectedCount: 9,
+			ExpectedCount: 3,
 		},
 		{
 			Name: "using data source (no issues)",
@@ -101,3 +101,83 @@ resource "aws_iam_role" "test" {
 		})
 	}
 }
+
+func Test_AwsServicePrincipalDNSSuffixRule_Fix(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Content  string
+		Expected string
+	}{
+		{
+			Name: "dns_suffix template",
+			Content: `
+data "aws_partition" "current" {}
+
+resource "aws_iam_role" "test" {
+  assume_role_policy = jsonencode({
+    Statement = [{
+      Principal = {
+        Service = "states.${data.aws_partition.current.dns_suffix}"
+      }
+    }]
+  })
+}`,
+			Expected: `
+data "aws_partition" "current" {}
+
+resource "aws_iam_role" "test" {
+  assume_role_policy = jsonencode({
+    Statement = [{
+      Principal = {
+        Service = data.aws_service_principal.states.name
+      }
+    }]
+  })
+}
+
+data "aws_service_principal" "states" {
+  service_name = "states"
+}
+`,
+		},
+		{
+			Name: "variable blocks are not fixed",
+			Content: `
+data "aws_partition" "current" {}
+
+variable "principal" {
+  type = string
+
+  validation {
+    condition     = var.principal != "states.${data.aws_partition.current.dns_suffix}"
+    error_message = "Use the principal of another service."
+  }
+}`,
+		},
+		{
+			Name: "dns_suffix inside a larger string is not fixed",
+			Content: `
+data "aws_partition" "current" {}
+
+resource "aws_sns_topic" "test" {
+  display_name = "Principal states.${data.aws_partition.current.dns_suffix}"
+}`,
+		},
+	}
+
+	rule := NewAwsServicePrincipalDNSSuffixRule()
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			runner := helper.TestRunner(t, map[string]string{"main.tf": test.Content})
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			if got := string(runner.Changes()["main.tf"]); got != test.Expected {
+				t.Errorf("Expected fixed content:\n%s\ngot:\n%s", test.Expected, got)
+			}
+		})
+	}
+}
@@ -12,6 +12,7 @@ import (
 // AwsServicePrincipalHardcodedRule checks for hardcoded AWS service principal DNS suffixes
 type AwsServicePrincipalHardcodedRule struct {
 	tflint.DefaultRule
+	withCatalog
 }
 
 // NewAwsServicePrincipalHardcodedRule returns a new rule
@@ -41,58 +42,120 @@ func (r *AwsServicePrincipalHardcodedRule) Link() string {
 
 // Check checks for hardcoded service principal DNS suffixes
 func (r *AwsServicePrincipalHardcodedRule) Check(runner tflint.Runner) error {
-	dnsSuffixPattern := awsmeta.GetDNSSuffixPattern()
+	return scanExpressions(runner, r)
+}
 
-	files, err := runner.GetFiles()
+func (r *AwsServicePrincipalHardcodedRule) detectors() ([]Detector, error) {
+	matcher, err := r.matcher()
 	if err != nil {
-		return err
+		return nil, err
 	}
+	return []Detector{servicePrincipalDetector{matcher}}, nil
+}
 
-	checked := make(map[string]bool)
+// servicePrincipalDetector finds service principals with a hardcoded DNS suffix
+type servicePrincipalDetector struct {
+	matcher *awsmeta.Matcher
+}
 
-	diags := runner.WalkExpressions(tflint.ExprWalkFunc(func(expr hcl.Expression) hcl.Diagnostics {
-		exprKey := fmt.Sprintf("%s:%d:%d", expr.Range().Filename, expr.Range().Start.Line, expr.Range().Start.Column)
-		if checked[exprKey] {
-			return nil
+// Candidate pre-filters on the raw source text for any known DNS suffix,
+// including one that follows an interpolation
+func (d servicePrincipalDetector) Candidate(expr hcl.Expression, src string) bool {
+	if _, ok := d.matcher.First(src, awsmeta.HitServicePrincipal); ok {
+		return true
+	}
+	for offset := 0; ; {
+		i := strings.Index(src[offset:], "}.")
+		if i < 0 {
+			return false
 		}
-		checked[exprKey] = true
-
-		// Pre-filter: check raw source for any known DNS suffix before making gRPC call
-		exprRange := expr.Range()
-		if file, ok := files[exprRange.Filename]; ok {
-			src := file.Bytes
-			if exprRange.Start.Byte < len(src) && exprRange.End.Byte <= len(src) {
-				sourceText := string(src[exprRange.Start.Byte:exprRange.End.Byte])
-				if !dnsSuffixPattern.MatchString(sourceText) {
-					return nil
-				}
-			}
+		offset += i + len("}.")
+		if _, ok := d.matcher.DNSSuffixAt(src, offset); ok {
+			return true
 		}
+	}
+}
 
-		err := runner.EvaluateExpr(expr, func(value string) error {
-			if matches := dnsSuffixPattern.FindStringSubmatch(value); len(matches) > 0 {
-				serviceName := matches[1]
+// Detect finds hardcoded service principals in the evaluated value
+func (d servicePrincipalDetector) Detect(value string) []Match {
+	hit, ok := d.matcher.First(value, awsmeta.HitServicePrincipal)
+	if !ok {
+		return nil
+	}
 
-				if err := runner.EmitIssue(
-					r,
-					fmt.Sprintf("Hardcoded service principal '%s' found. Consider using data.aws_service_principal.%s.name for multi-partition compatibility", value, strings.ReplaceAll(serviceName, "-", "_")),
-					expr.Range(),
-				); err != nil {
-					return err
-				}
-			}
+	replacement := fmt.Sprintf("data.aws_service_principal.%s.name", strings.ReplaceAll(hit.Service, "-", "_"))
+	return []Match{{
+		Kind:        kindServicePrincipal,
+		Value:       hit.Value,
+		Message:     fmt.Sprintf("Hardcoded service principal '%s' found. Consider using %s for multi-partition compatibility", hit.Value, replacement),
+		Replacement: replacement,
+	}}
+}
 
-			return nil
-		}, nil)
+// DetectTemplate also finds the DNS suffix of service principals whose
+// service name is not known, as in "${var.service}.amazonaws.com"
+func (d servicePrincipalDetector) DetectTemplate(text string) []Match {
+	matches := d.Detect(text)
 
-		_ = err
+	prefix := templatePlaceholder + "."
+	for offset := 0; ; {
+		i := strings.Index(text[offset:], prefix)
+		if i < 0 {
+			return matches
+		}
+		offset += i + len(prefix)
+
+		if suffix, ok := d.matcher.DNSSuffixAt(text, offset); ok {
+			matches = append(matches, Match{
+				Kind:    kindPrincipalSuffix,
+				Value:   suffix,
+				Message: fmt.Sprintf("Hardcoded DNS suffix '%s' found in service principal. Consider using data.aws_service_principal for multi-partition compatibility", suffix),
+			})
+		}
+	}
+}
 
-		return nil
-	}))
+// fixes replaces quoted service principals with a reference to the
+// aws_service_principal data source. Principals inside a larger string, such
+// as a heredoc policy, are left for a person to rewrite.
+func (r *AwsServicePrincipalHardcodedRule) fixes(findings []finding, ctx *fixContext) []func(tflint.Fixer) error {
+	fixes := make([]func(tflint.Fixer) error, len(findings))
 
-	if diags.HasErrors() {
-		return diags
+	// The findings were detected with this matcher, so it has loaded
+	matcher, err := r.matcher()
+	if err != nil {
+		return fixes
 	}
 
-	return nil
+	for i, f := range findings {
+		if strings.HasSuffix(f.Range.Filename, ".json") || f.Source != f.Value || !ctx.fixable(f.Range) {
+			continue
+		}
+
+		hit, ok := matcher.First(f.Value, awsmeta.HitServicePrincipal)
+		if !ok || hit.Start != 0 || hit.End != len(f.Value) {
+			continue
+		}
+
+		rng, ok := ctx.quotedRange(f.Range)
+		if !ok {
+			continue
+		}
+		fixes[i] = servicePrincipalFix(ctx, rng, hit.Service)
+	}
+
+	return fixes
+}
+
+// servicePrincipalFix replaces the range with data.aws_service_principal.<service>.name
+// and adds the data block when the module does not declare it yet
+func servicePrincipalFix(ctx *fixContext, rng hcl.Range, service string) func(tflint.Fixer) error {
+	name := strings.ReplaceAll(service, "-", "_")
+
+	return func(fixer tflint.Fixer) error {
+		if err := fixer.ReplaceText(rng, fmt.Sprintf("data.%s.%s.name", servicePrincipalDataSource, name)); err != nil {
+			return err
+		}
+		return ctx.data.ensure(fixer, rng.Filename, servicePrincipalDataSource, name, fmt.Sprintf("  service_name = %q\n", service))
+	}
 }
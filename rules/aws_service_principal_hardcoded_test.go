@@ -24,7 +24,7 @@ resource "aws_iam_role" "test" {
     }]
   })
 }`,
-			ExpectedCount: 2,
+			ExpectedCount: 1,
 		},
 		{
 			Name: "hardcoded service principal amazonaws.com.cn",
@@ -38,7 +38,7 @@ resource "aws_iam_role" "test" {
     }]
   })
 }`,
-			ExpectedCount: 2,
+			ExpectedCount: 1,
 		},
 		{
 			Name: "hardcoded service principal amazonaws-us-gov.com (legacy, not a real DNS suffix)",
@@ -66,7 +66,7 @@ resource "aws_iam_role" "test" {
     }]
   })
 }`,
-			ExpectedCount: 2,
+			ExpectedCount: 1,
 		},
 		{
 			Name: "hardcoded service principal eu sovereign amazonaws.eu",
@@ -80,7 +80,7 @@ resource "aws_iam_role" "test" {
     }]
   })
 }`,
-			ExpectedCount: 2,
+			ExpectedCount: 1,
 		},
 		{
 			Name: "multiple hardcoded service principals",
@@ -98,7 +98,23 @@ resource "aws_iam_role" "test" {
     }]
   })
 }`,
-			ExpectedCount: 6,
+			ExpectedCount: 3,
+		},
+		{
+			Name: "DNS suffix after an unknown service name",
+			Content: `
+variable "service" {}
+
+resource "aws_iam_role" "test" {
+  assume_role_policy = jsonencode({
+    Statement = [{
+      Principal = {
+        Service = ["${var.service}.amazonaws.com", "${var.service}.amazonaws.com.cn"]
+      }
+    }]
+  })
+}`,
+			ExpectedCount: 2,
 		},
 		{
 			Name: "using data source (no issues)",
@@ -155,3 +171,187 @@ resource "aws_iam_role" "test" {
 		})
 	}
 }
+
+func Test_AwsServicePrincipalHardcodedRule_AllowedValues(t *testing.T) {
+	tests := []struct {
+		Name          string
+		Config        string
+		Content       string
+		ExpectedCount int
+	}{
+		{
+			Name: "allowed service principal",
+			Config: `
+rule "aws_service_principal_hardcoded" {
+  enabled                    = true
+  allowed_service_principals = ["edgelambda.amazonaws.com"]
+}`,
+			Content: `
+resource "aws_iam_role" "test" {
+  assume_role_policy = jsonencode({
+    Statement = [{
+      Principal = {
+        Service = ["edgelambda.amazonaws.com", "lambda.amazonaws.com"]
+      }
+    }]
+  })
+}`,
+			ExpectedCount: 1,
+		},
+	}
+
+	rule := NewAwsServicePrincipalHardcodedRule()
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			runner := helper.TestRunner(t, map[string]string{"main.tf": test.Content, ".tflint.hcl": test.Config})
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			if len(runner.Issues) != test.ExpectedCount {
+				t.Errorf("Expected %d issues, got %d", test.ExpectedCount, len(runner.Issues))
+				for i, issue := range runner.Issues {
+					t.Logf("Issue %d: %s", i+1, issue.Message)
+				}
+			}
+		})
+	}
+}
+
+func Test_AwsServicePrincipalHardcodedRule_Fix(t *testing.T) {
+	tests := []struct {
+		Name         string
+		Content      string
+		Data         string
+		Expected     string
+		ExpectedData string
+	}{
+		{
+			Name: "quoted principal with existing data block",
+			Content: `
+resource "aws_iam_role" "test" {
+  assume_role_policy = jsonencode({
+    Statement = [{
+      Principal = {
+        Service = "s3.amazonaws.com"
+      }
+    }]
+  })
+}`,
+			Data: `
+data "aws_service_principal" "s3" {
+  service_name = "s3"
+}
+`,
+			Expected: `
+resource "aws_iam_role" "test" {
+  assume_role_policy = jsonencode({
+    Statement = [{
+      Principal = {
+        Service = data.aws_service_principal.s3.name
+      }
+    }]
+  })
+}`,
+		},
+		{
+			Name: "missing data blocks are added once",
+			Content: `
+resource "aws_iam_role" "test" {
+  assume_role_policy = jsonencode({
+    Statement = [{
+      Principal = {
+        Service = ["ecs-tasks.amazonaws.com", "lambda.amazonaws.com"]
+      }
+    }]
+  })
+}
+
+resource "aws_lambda_permission" "test" {
+  principal = "lambda.amazonaws.com"
+}`,
+			Data: `data "aws_region" "current" {}
+`,
+			Expected: `
+resource "aws_iam_role" "test" {
+  assume_role_policy = jsonencode({
+    Statement = [{
+      Principal = {
+        Service = [data.aws_service_principal.ecs_tasks.name, data.aws_service_principal.lambda.name]
+      }
+    }]
+  })
+}
+
+resource "aws_lambda_permission" "test" {
+  principal = data.aws_service_principal.lambda.name
+}`,
+			ExpectedData: `data "aws_region" "current" {}
+
+data "aws_service_principal" "ecs_tasks" {
+  service_name = "ecs-tasks"
+}
+
+data "aws_service_principal" "lambda" {
+  service_name = "lambda"
+}
+`,
+		},
+		{
+			Name: "principal with a longer DNS suffix",
+			Content: `
+resource "aws_lambda_permission" "test" {
+  principal = "lambda.amazonaws.com.cn"
+}`,
+			Data: `
+data "aws_service_principal" "lambda" {
+  service_name = "lambda"
+}
+`,
+			Expected: `
+resource "aws_lambda_permission" "test" {
+  principal = data.aws_service_principal.lambda.name
+}`,
+		},
+		{
+			Name: "variable defaults are not fixed",
+			Content: `
+variable "principal" {
+  default = "lambda.amazonaws.com"
+}`,
+		},
+		{
+			Name: "principal inside a larger string is not fixed",
+			Content: `
+resource "aws_iam_policy" "test" {
+  description = "Allows s3.amazonaws.com"
+}`,
+		},
+	}
+
+	rule := NewAwsServicePrincipalHardcodedRule()
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			files := map[string]string{"main.tf": test.Content}
+			if test.Data != "" {
+				files["data.tf"] = test.Data
+			}
+			runner := helper.TestRunner(t, files)
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			changes := runner.Changes()
+			if got := string(changes["main.tf"]); got != test.Expected {
+				t.Errorf("Expected fixed content:\n%s\ngot:\n%s", test.Expected, got)
+			}
+			if got := string(changes["data.tf"]); got != test.ExpectedData {
+				t.Errorf("Expected fixed data.tf:\n%s\ngot:\n%s", test.ExpectedData, got)
+			}
+		})
+	}
+}
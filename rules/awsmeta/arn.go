@@ -0,0 +1,75 @@
+package awsmeta
+
+import (
+	"strings"
+)
+
+// ARNField is the byte range of one field of an ARN within a text
+type ARNField struct {
+	Start int
+	End   int
+}
+
+// ARNFields holds the leading fields of an ARN found by FindARNFields.
+// A field is nil when it is not literal text, for example when it is built
+// from a template interpolation.
+type ARNFields struct {
+	Partition *ARNField
+	Service   *ARNField
+	Region    *ARNField
+	Account   *ARNField
+}
+
+// FindARNFields finds the ARNs in template source text and returns the byte
+// ranges of their partition, service, region and account fields. Scanning a
+// field stops at interpolations, directives, quotes, escapes and whitespace,
+// so only fields written out as literal text are returned.
+func FindARNFields(src string) []ARNFields {
+	var arns []ARNFields
+
+	offset := 0
+	for {
+		idx := strings.Index(src[offset:], "arn:")
+		if idx < 0 {
+			return arns
+		}
+		at := offset + idx
+		start := at + len("arn:")
+		offset = start
+
+		// Skip words that merely end in "arn", such as "learn:"
+		if at > 0 && isWordByte(src[at-1]) {
+			continue
+		}
+
+		var fields []*ARNField
+		pos := start
+		for len(fields) < 4 {
+			end := strings.IndexAny(src[pos:], ":${}%\"\\ \t\r\n")
+			if end < 0 || src[pos+end] != ':' {
+				break
+			}
+			fields = append(fields, &ARNField{Start: pos, End: pos + end})
+			pos += end + 1
+		}
+
+		var arn ARNFields
+		for i, field := range fields {
+			switch i {
+			case 0:
+				arn.Partition = field
+			case 1:
+				arn.Service = field
+			case 2:
+				arn.Region = field
+			case 3:
+				arn.Account = field
+			}
+		}
+		arns = append(arns, arn)
+	}
+}
+
+func isWordByte(c byte) bool {
+	return c == '_' || c == '-' || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9')
+}
@@ -0,0 +1,54 @@
+package awsmeta
+
+import (
+	"testing"
+)
+
+func TestFindARNFields(t *testing.T) {
+	// fields returns the text of the partition, service, region and account
+	// fields, with "-" for fields that were not found
+	fields := func(src string, arn ARNFields) []string {
+		var texts []string
+		for _, field := range []*ARNField{arn.Partition, arn.Service, arn.Region, arn.Account} {
+			if field == nil {
+				texts = append(texts, "-")
+			} else {
+				texts = append(texts, src[field.Start:field.End])
+			}
+		}
+		return texts
+	}
+
+	testCases := []struct {
+		src      string
+		expected [][]string
+	}{
+		{"arn:aws:s3:eu-west-1:123456789012:bucket/my-bucket", [][]string{{"aws", "s3", "eu-west-1", "123456789012"}}},
+		{"arn:aws:s3:::my-bucket/*", [][]string{{"aws", "s3", "", ""}}},
+		{"arn:aws:sqs:${var.region}:123456789012:queue", [][]string{{"aws", "sqs", "-", "-"}}},
+		{"arn:${var.partition}:s3:::my-bucket", [][]string{{"-", "-", "-", "-"}}},
+		{`["arn:aws:sns:us-east-1:123456789012:a", "arn:aws-cn:sns:cn-north-1:123456789012:b"]`, [][]string{
+			{"aws", "sns", "us-east-1", "123456789012"},
+			{"aws-cn", "sns", "cn-north-1", "123456789012"},
+		}},
+		{"learn:aws:s3:::my-bucket", nil},
+		{"no ARN here", nil},
+	}
+
+	for _, tc := range testCases {
+		arns := FindARNFields(tc.src)
+		if len(arns) != len(tc.expected) {
+			t.Errorf("%s: expected %d ARNs, got %d", tc.src, len(tc.expected), len(arns))
+			continue
+		}
+		for i, arn := range arns {
+			got := fields(tc.src, arn)
+			for j := range got {
+				if got[j] != tc.expected[i][j] {
+					t.Errorf("%s: expected fields %v, got %v", tc.src, tc.expected[i], got)
+					break
+				}
+			}
+		}
+	}
+}
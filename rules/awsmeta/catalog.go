@@ -0,0 +1,49 @@
+package awsmeta
+
+import "slices"
+
+// Catalog lists the AWS regions and partitions that hardcoded values are
+// matched against
+type Catalog interface {
+	// Regions returns the region codes, such as "eu-west-1"
+	Regions() ([]string, error)
+
+	// AvailabilityZoneSuffix returns the pattern that follows a region code in
+	// the name of one of its availability zones, such as "[a-z]" for "eu-west-1a"
+	AvailabilityZoneSuffix() string
+
+	// Partitions returns the partitions, such as "aws" and "aws-cn"
+	Partitions() ([]Partition, error)
+}
+
+// Partition is an AWS partition and the DNS suffix of its service endpoints
+type Partition struct {
+	ID        string
+	DNSSuffix string
+}
+
+//go:generate go run ./gencatalog
+
+// DefaultCatalog returns the catalog generated from github.com/myerscode/aws-meta
+// at CatalogVersion. It is made of static tables, so it never fails to load.
+func DefaultCatalog() Catalog {
+	return embeddedCatalog{}
+}
+
+type embeddedCatalog struct{}
+
+// embeddedZoneSuffix is the compiled availability zone suffix of the default
+// catalog, which is known to be valid
+var embeddedZoneSuffix, _ = compileZoneSuffix(embeddedCatalog{}.AvailabilityZoneSuffix())
+
+func (embeddedCatalog) Regions() ([]string, error) {
+	return slices.Clone(catalogRegions), nil
+}
+
+func (embeddedCatalog) AvailabilityZoneSuffix() string {
+	return "[a-z]"
+}
+
+func (embeddedCatalog) Partitions() ([]Partition, error) {
+	return slices.Clone(catalogPartitions), nil
+}
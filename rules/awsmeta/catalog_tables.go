@@ -0,0 +1,1889 @@
+// Code generated by gencatalog from github.com/myerscode/aws-meta v0.103.0. DO NOT EDIT.
+
+package awsmeta
+
+// CatalogVersion is the version of github.com/myerscode/aws-meta the default
+// catalog was generated from
+const CatalogVersion = "v0.103.0"
+
+var catalogRegions = []string{
+	"af-south-1",        // Africa (Cape Town)
+	"ap-east-1",         // Asia Pacific (Hong Kong)
+	"ap-east-2",         // Asia Pacific (Taipei)
+	"ap-northeast-1",    // Asia Pacific (Tokyo)
+	"ap-northeast-2",    // Asia Pacific (Seoul)
+	"ap-northeast-3",    // Asia Pacific (Osaka)
+	"ap-south-1",        // Asia Pacific (Mumbai)
+	"ap-south-2",        // Asia Pacific (Hyderabad)
+	"ap-southeast-1",    // Asia Pacific (Singapore)
+	"ap-southeast-2",    // Asia Pacific (Sydney)
+	"ap-southeast-3",    // Asia Pacific (Jakarta)
+	"ap-southeast-4",    // Asia Pacific (Melbourne)
+	"ap-southeast-5",    // Asia Pacific (Malaysia)
+	"ap-southeast-6",    // Asia Pacific (New Zealand)
+	"ap-southeast-7",    // Asia Pacific (Thailand)
+	"aws-global",        // aws global region
+	"ca-central-1",      // Canada (Central)
+	"ca-west-1",         // Canada West (Calgary)
+	"eu-central-1",      // Europe (Frankfurt)
+	"eu-central-2",      // Europe (Zurich)
+	"eu-north-1",        // Europe (Stockholm)
+	"eu-south-1",        // Europe (Milan)
+	"eu-south-2",        // Europe (Spain)
+	"eu-west-1",         // Europe (Ireland)
+	"eu-west-2",         // Europe (London)
+	"eu-west-3",         // Europe (Paris)
+	"il-central-1",      // Israel (Tel Aviv)
+	"me-central-1",      // Middle East (UAE)
+	"me-south-1",        // Middle East (Bahrain)
+	"mx-central-1",      // Mexico (Central)
+	"sa-east-1",         // South America (Sao Paulo)
+	"us-east-1",         // US East (N. Virginia)
+	"us-east-2",         // US East (Ohio)
+	"us-west-1",         // US West (N. California)
+	"us-west-2",         // US West (Oregon)
+	"aws-cn-global",     // aws-cn global region
+	"cn-north-1",        // China (Beijing)
+	"cn-northwest-1",    // China (Ningxia)
+	"eusc-de-east-1",    // AWS European Sovereign Cloud (Germany)
+	"aws-iso-global",    // aws-iso global region
+	"us-iso-east-1",     // US ISO East
+	"us-iso-west-1",     // US ISO WEST
+	"aws-iso-b-global",  // aws-iso-b global region
+	"us-isob-east-1",    // US ISOB East (Ohio)
+	"us-isob-west-1",    // US ISOB West
+	"aws-iso-e-global",  // aws-iso-e global region
+	"eu-isoe-west-1",    // EU ISOE West
+	"aws-iso-f-global",  // aws-iso-f global region
+	"us-isof-east-1",    // US ISOF EAST
+	"us-isof-south-1",   // US ISOF SOUTH
+	"aws-us-gov-global", // aws-us-gov global region
+	"us-gov-east-1",     // AWS GovCloud (US-East)
+	"us-gov-west-1",     // AWS GovCloud (US-West)
+}
+
+var catalogPartitions = []Partition{
+	{ID: "aws", DNSSuffix: "amazonaws.com"},
+	{ID: "aws-cn", DNSSuffix: "amazonaws.com.cn"},
+	{ID: "aws-eusc", DNSSuffix: "amazonaws.eu"},
+	{ID: "aws-iso", DNSSuffix: "c2s.ic.gov"},
+	{ID: "aws-iso-b", DNSSuffix: "sc2s.sgov.gov"},
+	{ID: "aws-iso-e", DNSSuffix: "cloud.adc-e.uk"},
+	{ID: "aws-iso-f", DNSSuffix: "csp.hci.ic.gov"},
+	{ID: "aws-us-gov", DNSSuffix: "amazonaws.com"},
+}
+
+var catalogPartitionIDs = map[string]bool{
+	"aws":        true,
+	"aws-cn":     true,
+	"aws-eusc":   true,
+	"aws-iso":    true,
+	"aws-iso-b":  true,
+	"aws-iso-e":  true,
+	"aws-iso-f":  true,
+	"aws-us-gov": true,
+}
+
+const catalogMaxPartition = 10
+
+var catalogRegionTrie = &trie{
+	labels: []byte("aceimsu"),
+	children: []*trie{
+		{
+			labels: []byte("fpw"),
+			children: []*trie{
+				{
+					labels: []byte("-"),
+					children: []*trie{
+						{
+							labels: []byte("s"),
+							children: []*trie{
+								{
+									labels: []byte("o"),
+									children: []*trie{
+										{
+											labels: []byte("u"),
+											children: []*trie{
+												{
+													labels: []byte("t"),
+													children: []*trie{
+														{
+															labels: []byte("h"),
+															children: []*trie{
+																{
+																	labels: []byte("-"),
+																	children: []*trie{
+																		{
+																			labels: []byte("1"),
+																			children: []*trie{
+																				{terminal: true},
+																			},
+																		},
+																	},
+																},
+															},
+														},
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				{
+					labels: []byte("-"),
+					children: []*trie{
+						{
+							labels: []byte("ens"),
+							children: []*trie{
+								{
+									labels: []byte("a"),
+									children: []*trie{
+										{
+											labels: []byte("s"),
+											children: []*trie{
+												{
+													labels: []byte("t"),
+													children: []*trie{
+														{
+															labels: []byte("-"),
+															children: []*trie{
+																{
+																	labels: []byte("12"),
+																	children: []*trie{
+																		{terminal: true},
+																		{terminal: true},
+																	},
+																},
+															},
+														},
+													},
+												},
+											},
+										},
+									},
+								},
+								{
+									labels: []byte("o"),
+									children: []*trie{
+										{
+											labels: []byte("r"),
+											children: []*trie{
+												{
+													labels: []byte("t"),
+													children: []*trie{
+														{
+															labels: []byte("h"),
+															children: []*trie{
+																{
+																	labels: []byte("e"),
+																	children: []*trie{
+																		{
+																			labels: []byte("a"),
+																			children: []*trie{
+																				{
+																					labels: []byte("s"),
+																					children: []*trie{
+																						{
+																							labels: []byte("t"),
+																							children: []*trie{
+																								{
+																									labels: []byte("-"),
+																									children: []*trie{
+																										{
+																											labels: []byte("123"),
+																											children: []*trie{
+																												{terminal: true},
+																												{terminal: true},
+																												{terminal: true},
+																											},
+																										},
+																									},
+																								},
+																							},
+																						},
+																					},
+																				},
+																			},
+																		},
+																	},
+																},
+															},
+														},
+													},
+												},
+											},
+										},
+									},
+								},
+								{
+									labels: []byte("o"),
+									children: []*trie{
+										{
+											labels: []byte("u"),
+											children: []*trie{
+												{
+													labels: []byte("t"),
+													children: []*trie{
+														{
+															labels: []byte("h"),
+															children: []*trie{
+																{
+																	labels: []byte("-e"),
+																	children: []*trie{
+																		{
+																			labels: []byte("12"),
+																			children: []*trie{
+																				{terminal: true},
+																				{terminal: true},
+																			},
+																		},
+																		{
+																			labels: []byte("a"),
+																			children: []*trie{
+																				{
+																					labels: []byte("s"),
+																					children: []*trie{
+																						{
+																							labels: []byte("t"),
+																							children: []*trie{
+																								{
+																									labels: []byte("-"),
+																									children: []*trie{
+																										{
+																											labels: []byte("1234567"),
+																											children: []*trie{
+																												{terminal: true},
+																												{terminal: true},
+																												{terminal: true},
+																												{terminal: true},
+																												{terminal: true},
+																												{terminal: true},
+																												{terminal: true},
+																											},
+																										},
+																									},
+																								},
+																							},
+																						},
+																					},
+																				},
+																			},
+																		},
+																	},
+																},
+															},
+														},
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				{
+					labels: []byte("s"),
+					children: []*trie{
+						{
+							labels: []byte("-"),
+							children: []*trie{
+								{
+									labels: []byte("gciu"),
+									children: []*trie{
+										{
+											labels: []byte("l"),
+											children: []*trie{
+												{
+													labels: []byte("o"),
+													children: []*trie{
+														{
+															labels: []byte("b"),
+															children: []*trie{
+																{
+																	labels: []byte("a"),
+																	children: []*trie{
+																		{
+																			labels: []byte("l"),
+																			children: []*trie{
+																				{terminal: true},
+																			},
+																		},
+																	},
+																},
+															},
+														},
+													},
+												},
+											},
+										},
+										{
+											labels: []byte("n"),
+											children: []*trie{
+												{
+													labels: []byte("-"),
+													children: []*trie{
+														{
+															labels: []byte("g"),
+															children: []*trie{
+																{
+																	labels: []byte("l"),
+																	children: []*trie{
+																		{
+																			labels: []byte("o"),
+																			children: []*trie{
+																				{
+																					labels: []byte("b"),
+																					children: []*trie{
+																						{
+																							labels: []byte("a"),
+																							children: []*trie{
+																								{
+																									labels: []byte("l"),
+																									children: []*trie{
+																										{terminal: true},
+																									},
+																								},
+																							},
+																						},
+																					},
+																				},
+																			},
+																		},
+																	},
+																},
+															},
+														},
+													},
+												},
+											},
+										},
+										{
+											labels: []byte("s"),
+											children: []*trie{
+												{
+													labels: []byte("o"),
+													children: []*trie{
+														{
+															labels: []byte("-"),
+															children: []*trie{
+																{
+																	labels: []byte("gbef"),
+																	children: []*trie{
+																		{
+																			labels: []byte("l"),
+																			children: []*trie{
+																				{
+																					labels: []byte("o"),
+																					children: []*trie{
+																						{
+																							labels: []byte("b"),
+																							children: []*trie{
+																								{
+																									labels: []byte("a"),
+																									children: []*trie{
+																										{
+																											labels: []byte("l"),
+																											children: []*trie{
+																												{terminal: true},
+																											},
+																										},
+																									},
+																								},
+																							},
+																						},
+																					},
+																				},
+																			},
+																		},
+																		{
+																			labels: []byte("-"),
+																			children: []*trie{
+																				{
+																					labels: []byte("g"),
+																					children: []*trie{
+																						{
+																							labels: []byte("l"),
+																							children: []*trie{
+																								{
+																									labels: []byte("o"),
+																									children: []*trie{
+																										{
+																											labels: []byte("b"),
+																											children: []*trie{
+																												{
+																													labels: []byte("a"),
+																													children: []*trie{
+																														{
+																															labels: []byte("l"),
+																															children: []*trie{
+																																{terminal: true},
+																															},
+																														},
+																													},
+																												},
+																											},
+																										},
+																									},
+																								},
+																							},
+																						},
+																					},
+																				},
+																			},
+																		},
+																		{
+																			labels: []byte("-"),
+																			children: []*trie{
+																				{
+																					labels: []byte("g"),
+																					children: []*trie{
+																						{
+																							labels: []byte("l"),
+																							children: []*trie{
+																								{
+																									labels: []byte("o"),
+																									children: []*trie{
+																										{
+																											labels: []byte("b"),
+																											children: []*trie{
+																												{
+																													labels: []byte("a"),
+																													children: []*trie{
+																														{
+																															labels: []byte("l"),
+																															children: []*trie{
+																																{terminal: true},
+																															},
+																														},
+																													},
+																												},
+																											},
+																										},
+																									},
+																								},
+																							},
+																						},
+																					},
+																				},
+																			},
+																		},
+																		{
+																			labels: []byte("-"),
+																			children: []*trie{
+																				{
+																					labels: []byte("g"),
+																					children: []*trie{
+																						{
+																							labels: []byte("l"),
+																							children: []*trie{
+																								{
+																									labels: []byte("o"),
+																									children: []*trie{
+																										{
+																											labels: []byte("b"),
+																											children: []*trie{
+																												{
+																													labels: []byte("a"),
+																													children: []*trie{
+																														{
+																															labels: []byte("l"),
+																															children: []*trie{
+																																{terminal: true},
+																															},
+																														},
+																													},
+																												},
+																											},
+																										},
+																									},
+																								},
+																							},
+																						},
+																					},
+																				},
+																			},
+																		},
+																	},
+																},
+															},
+														},
+													},
+												},
+											},
+										},
+										{
+											labels: []byte("s"),
+											children: []*trie{
+												{
+													labels: []byte("-"),
+													children: []*trie{
+														{
+															labels: []byte("g"),
+															children: []*trie{
+																{
+																	labels: []byte("o"),
+																	children: []*trie{
+																		{
+																			labels: []byte("v"),
+																			children: []*trie{
+																				{
+																					labels: []byte("-"),
+																					children: []*trie{
+																						{
+																							labels: []byte("g"),
+																							children: []*trie{
+																								{
+																									labels: []byte("l"),
+																									children: []*trie{
+																										{
+																											labels: []byte("o"),
+																											children: []*trie{
+																												{
+																													labels: []byte("b"),
+																													children: []*trie{
+																														{
+																															labels: []byte("a"),
+																															children: []*trie{
+																																{
+																																	labels: []byte("l"),
+																																	children: []*trie{
+																																		{terminal: true},
+																																	},
+																																},
+																															},
+																														},
+																													},
+																												},
+																											},
+																										},
+																									},
+																								},
+																							},
+																						},
+																					},
+																				},
+																			},
+																		},
+																	},
+																},
+															},
+														},
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			labels: []byte("an"),
+			children: []*trie{
+				{
+					labels: []byte("-"),
+					children: []*trie{
+						{
+							labels: []byte("cw"),
+							children: []*trie{
+								{
+									labels: []byte("e"),
+									children: []*trie{
+										{
+											labels: []byte("n"),
+											children: []*trie{
+												{
+													labels: []byte("t"),
+													children: []*trie{
+														{
+															labels: []byte("r"),
+															children: []*trie{
+																{
+																	labels: []byte("a"),
+																	children: []*trie{
+																		{
+																			labels: []byte("l"),
+																			children: []*trie{
+																				{
+																					labels: []byte("-"),
+																					children: []*trie{
+																						{
+																							labels: []byte("1"),
+																							children: []*trie{
+																								{terminal: true},
+																							},
+																						},
+																					},
+																				},
+																			},
+																		},
+																	},
+																},
+															},
+														},
+													},
+												},
+											},
+										},
+									},
+								},
+								{
+									labels: []byte("e"),
+									children: []*trie{
+										{
+											labels: []byte("s"),
+											children: []*trie{
+												{
+													labels: []byte("t"),
+													children: []*trie{
+														{
+															labels: []byte("-"),
+															children: []*trie{
+																{
+																	labels: []byte("1"),
+																	children: []*trie{
+																		{terminal: true},
+																	},
+																},
+															},
+														},
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				{
+					labels: []byte("-"),
+					children: []*trie{
+						{
+							labels: []byte("n"),
+							children: []*trie{
+								{
+									labels: []byte("o"),
+									children: []*trie{
+										{
+											labels: []byte("r"),
+											children: []*trie{
+												{
+													labels: []byte("t"),
+													children: []*trie{
+														{
+															labels: []byte("h"),
+															children: []*trie{
+																{
+																	labels: []byte("-w"),
+																	children: []*trie{
+																		{
+																			labels: []byte("1"),
+																			children: []*trie{
+																				{terminal: true},
+																			},
+																		},
+																		{
+																			labels: []byte("e"),
+																			children: []*trie{
+																				{
+																					labels: []byte("s"),
+																					children: []*trie{
+																						{
+																							labels: []byte("t"),
+																							children: []*trie{
+																								{
+																									labels: []byte("-"),
+																									children: []*trie{
+																										{
+																											labels: []byte("1"),
+																											children: []*trie{
+																												{terminal: true},
+																											},
+																										},
+																									},
+																								},
+																							},
+																						},
+																					},
+																				},
+																			},
+																		},
+																	},
+																},
+															},
+														},
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			labels: []byte("u"),
+			children: []*trie{
+				{
+					labels: []byte("-s"),
+					children: []*trie{
+						{
+							labels: []byte("cnswi"),
+							children: []*trie{
+								{
+									labels: []byte("e"),
+									children: []*trie{
+										{
+											labels: []byte("n"),
+											children: []*trie{
+												{
+													labels: []byte("t"),
+													children: []*trie{
+														{
+															labels: []byte("r"),
+															children: []*trie{
+																{
+																	labels: []byte("a"),
+																	children: []*trie{
+																		{
+																			labels: []byte("l"),
+																			children: []*trie{
+																				{
+																					labels: []byte("-"),
+																					children: []*trie{
+																						{
+																							labels: []byte("12"),
+																							children: []*trie{
+																								{terminal: true},
+																								{terminal: true},
+																							},
+																						},
+																					},
+																				},
+																			},
+																		},
+																	},
+																},
+															},
+														},
+													},
+												},
+											},
+										},
+									},
+								},
+								{
+									labels: []byte("o"),
+									children: []*trie{
+										{
+											labels: []byte("r"),
+											children: []*trie{
+												{
+													labels: []byte("t"),
+													children: []*trie{
+														{
+															labels: []byte("h"),
+															children: []*trie{
+																{
+																	labels: []byte("-"),
+																	children: []*trie{
+																		{
+																			labels: []byte("1"),
+																			children: []*trie{
+																				{terminal: true},
+																			},
+																		},
+																	},
+																},
+															},
+														},
+													},
+												},
+											},
+										},
+									},
+								},
+								{
+									labels: []byte("o"),
+									children: []*trie{
+										{
+											labels: []byte("u"),
+											children: []*trie{
+												{
+													labels: []byte("t"),
+													children: []*trie{
+														{
+															labels: []byte("h"),
+															children: []*trie{
+																{
+																	labels: []byte("-"),
+																	children: []*trie{
+																		{
+																			labels: []byte("12"),
+																			children: []*trie{
+																				{terminal: true},
+																				{terminal: true},
+																			},
+																		},
+																	},
+																},
+															},
+														},
+													},
+												},
+											},
+										},
+									},
+								},
+								{
+									labels: []byte("e"),
+									children: []*trie{
+										{
+											labels: []byte("s"),
+											children: []*trie{
+												{
+													labels: []byte("t"),
+													children: []*trie{
+														{
+															labels: []byte("-"),
+															children: []*trie{
+																{
+																	labels: []byte("123"),
+																	children: []*trie{
+																		{terminal: true},
+																		{terminal: true},
+																		{terminal: true},
+																	},
+																},
+															},
+														},
+													},
+												},
+											},
+										},
+									},
+								},
+								{
+									labels: []byte("s"),
+									children: []*trie{
+										{
+											labels: []byte("o"),
+											children: []*trie{
+												{
+													labels: []byte("e"),
+													children: []*trie{
+														{
+															labels: []byte("-"),
+															children: []*trie{
+																{
+																	labels: []byte("w"),
+																	children: []*trie{
+																		{
+																			labels: []byte("e"),
+																			children: []*trie{
+																				{
+																					labels: []byte("s"),
+																					children: []*trie{
+																						{
+																							labels: []byte("t"),
+																							children: []*trie{
+																								{
+																									labels: []byte("-"),
+																									children: []*trie{
+																										{
+																											labels: []byte("1"),
+																											children: []*trie{
+																												{terminal: true},
+																											},
+																										},
+																									},
+																								},
+																							},
+																						},
+																					},
+																				},
+																			},
+																		},
+																	},
+																},
+															},
+														},
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+						{
+							labels: []byte("c"),
+							children: []*trie{
+								{
+									labels: []byte("-"),
+									children: []*trie{
+										{
+											labels: []byte("d"),
+											children: []*trie{
+												{
+													labels: []byte("e"),
+													children: []*trie{
+														{
+															labels: []byte("-"),
+															children: []*trie{
+																{
+																	labels: []byte("e"),
+																	children: []*trie{
+																		{
+																			labels: []byte("a"),
+																			children: []*trie{
+																				{
+																					labels: []byte("s"),
+																					children: []*trie{
+																						{
+																							labels: []byte("t"),
+																							children: []*trie{
+																								{
+																									labels: []byte("-"),
+																									children: []*trie{
+																										{
+																											labels: []byte("1"),
+																											children: []*trie{
+																												{terminal: true},
+																											},
+																										},
+																									},
+																								},
+																							},
+																						},
+																					},
+																				},
+																			},
+																		},
+																	},
+																},
+															},
+														},
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			labels: []byte("l"),
+			children: []*trie{
+				{
+					labels: []byte("-"),
+					children: []*trie{
+						{
+							labels: []byte("c"),
+							children: []*trie{
+								{
+									labels: []byte("e"),
+									children: []*trie{
+										{
+											labels: []byte("n"),
+											children: []*trie{
+												{
+													labels: []byte("t"),
+													children: []*trie{
+														{
+															labels: []byte("r"),
+															children: []*trie{
+																{
+																	labels: []byte("a"),
+																	children: []*trie{
+																		{
+																			labels: []byte("l"),
+																			children: []*trie{
+																				{
+																					labels: []byte("-"),
+																					children: []*trie{
+																						{
+																							labels: []byte("1"),
+																							children: []*trie{
+																								{terminal: true},
+																							},
+																						},
+																					},
+																				},
+																			},
+																		},
+																	},
+																},
+															},
+														},
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			labels: []byte("ex"),
+			children: []*trie{
+				{
+					labels: []byte("-"),
+					children: []*trie{
+						{
+							labels: []byte("cs"),
+							children: []*trie{
+								{
+									labels: []byte("e"),
+									children: []*trie{
+										{
+											labels: []byte("n"),
+											children: []*trie{
+												{
+													labels: []byte("t"),
+													children: []*trie{
+														{
+															labels: []byte("r"),
+															children: []*trie{
+																{
+																	labels: []byte("a"),
+																	children: []*trie{
+																		{
+																			labels: []byte("l"),
+																			children: []*trie{
+																				{
+																					labels: []byte("-"),
+																					children: []*trie{
+																						{
+																							labels: []byte("1"),
+																							children: []*trie{
+																								{terminal: true},
+																							},
+																						},
+																					},
+																				},
+																			},
+																		},
+																	},
+																},
+															},
+														},
+													},
+												},
+											},
+										},
+									},
+								},
+								{
+									labels: []byte("o"),
+									children: []*trie{
+										{
+											labels: []byte("u"),
+											children: []*trie{
+												{
+													labels: []byte("t"),
+													children: []*trie{
+														{
+															labels: []byte("h"),
+															children: []*trie{
+																{
+																	labels: []byte("-"),
+																	children: []*trie{
+																		{
+																			labels: []byte("1"),
+																			children: []*trie{
+																				{terminal: true},
+																			},
+																		},
+																	},
+																},
+															},
+														},
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				{
+					labels: []byte("-"),
+					children: []*trie{
+						{
+							labels: []byte("c"),
+							children: []*trie{
+								{
+									labels: []byte("e"),
+									children: []*trie{
+										{
+											labels: []byte("n"),
+											children: []*trie{
+												{
+													labels: []byte("t"),
+													children: []*trie{
+														{
+															labels: []byte("r"),
+															children: []*trie{
+																{
+																	labels: []byte("a"),
+																	children: []*trie{
+																		{
+																			labels: []byte("l"),
+																			children: []*trie{
+																				{
+																					labels: []byte("-"),
+																					children: []*trie{
+																						{
+																							labels: []byte("1"),
+																							children: []*trie{
+																								{terminal: true},
+																							},
+																						},
+																					},
+																				},
+																			},
+																		},
+																	},
+																},
+															},
+														},
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			labels: []byte("a"),
+			children: []*trie{
+				{
+					labels: []byte("-"),
+					children: []*trie{
+						{
+							labels: []byte("e"),
+							children: []*trie{
+								{
+									labels: []byte("a"),
+									children: []*trie{
+										{
+											labels: []byte("s"),
+											children: []*trie{
+												{
+													labels: []byte("t"),
+													children: []*trie{
+														{
+															labels: []byte("-"),
+															children: []*trie{
+																{
+																	labels: []byte("1"),
+																	children: []*trie{
+																		{terminal: true},
+																	},
+																},
+															},
+														},
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			labels: []byte("s"),
+			children: []*trie{
+				{
+					labels: []byte("-"),
+					children: []*trie{
+						{
+							labels: []byte("ewig"),
+							children: []*trie{
+								{
+									labels: []byte("a"),
+									children: []*trie{
+										{
+											labels: []byte("s"),
+											children: []*trie{
+												{
+													labels: []byte("t"),
+													children: []*trie{
+														{
+															labels: []byte("-"),
+															children: []*trie{
+																{
+																	labels: []byte("12"),
+																	children: []*trie{
+																		{terminal: true},
+																		{terminal: true},
+																	},
+																},
+															},
+														},
+													},
+												},
+											},
+										},
+									},
+								},
+								{
+									labels: []byte("e"),
+									children: []*trie{
+										{
+											labels: []byte("s"),
+											children: []*trie{
+												{
+													labels: []byte("t"),
+													children: []*trie{
+														{
+															labels: []byte("-"),
+															children: []*trie{
+																{
+																	labels: []byte("12"),
+																	children: []*trie{
+																		{terminal: true},
+																		{terminal: true},
+																	},
+																},
+															},
+														},
+													},
+												},
+											},
+										},
+									},
+								},
+								{
+									labels: []byte("s"),
+									children: []*trie{
+										{
+											labels: []byte("o"),
+											children: []*trie{
+												{
+													labels: []byte("-bf"),
+													children: []*trie{
+														{
+															labels: []byte("ew"),
+															children: []*trie{
+																{
+																	labels: []byte("a"),
+																	children: []*trie{
+																		{
+																			labels: []byte("s"),
+																			children: []*trie{
+																				{
+																					labels: []byte("t"),
+																					children: []*trie{
+																						{
+																							labels: []byte("-"),
+																							children: []*trie{
+																								{
+																									labels: []byte("1"),
+																									children: []*trie{
+																										{terminal: true},
+																									},
+																								},
+																							},
+																						},
+																					},
+																				},
+																			},
+																		},
+																	},
+																},
+																{
+																	labels: []byte("e"),
+																	children: []*trie{
+																		{
+																			labels: []byte("s"),
+																			children: []*trie{
+																				{
+																					labels: []byte("t"),
+																					children: []*trie{
+																						{
+																							labels: []byte("-"),
+																							children: []*trie{
+																								{
+																									labels: []byte("1"),
+																									children: []*trie{
+																										{terminal: true},
+																									},
+																								},
+																							},
+																						},
+																					},
+																				},
+																			},
+																		},
+																	},
+																},
+															},
+														},
+														{
+															labels: []byte("-"),
+															children: []*trie{
+																{
+																	labels: []byte("ew"),
+																	children: []*trie{
+																		{
+																			labels: []byte("a"),
+																			children: []*trie{
+																				{
+																					labels: []byte("s"),
+																					children: []*trie{
+																						{
+																							labels: []byte("t"),
+																							children: []*trie{
+																								{
+																									labels: []byte("-"),
+																									children: []*trie{
+																										{
+																											labels: []byte("1"),
+																											children: []*trie{
+																												{terminal: true},
+																											},
+																										},
+																									},
+																								},
+																							},
+																						},
+																					},
+																				},
+																			},
+																		},
+																		{
+																			labels: []byte("e"),
+																			children: []*trie{
+																				{
+																					labels: []byte("s"),
+																					children: []*trie{
+																						{
+																							labels: []byte("t"),
+																							children: []*trie{
+																								{
+																									labels: []byte("-"),
+																									children: []*trie{
+																										{
+																											labels: []byte("1"),
+																											children: []*trie{
+																												{terminal: true},
+																											},
+																										},
+																									},
+																								},
+																							},
+																						},
+																					},
+																				},
+																			},
+																		},
+																	},
+																},
+															},
+														},
+														{
+															labels: []byte("-"),
+															children: []*trie{
+																{
+																	labels: []byte("es"),
+																	children: []*trie{
+																		{
+																			labels: []byte("a"),
+																			children: []*trie{
+																				{
+																					labels: []byte("s"),
+																					children: []*trie{
+																						{
+																							labels: []byte("t"),
+																							children: []*trie{
+																								{
+																									labels: []byte("-"),
+																									children: []*trie{
+																										{
+																											labels: []byte("1"),
+																											children: []*trie{
+																												{terminal: true},
+																											},
+																										},
+																									},
+																								},
+																							},
+																						},
+																					},
+																				},
+																			},
+																		},
+																		{
+																			labels: []byte("o"),
+																			children: []*trie{
+																				{
+																					labels: []byte("u"),
+																					children: []*trie{
+																						{
+																							labels: []byte("t"),
+																							children: []*trie{
+																								{
+																									labels: []byte("h"),
+																									children: []*trie{
+																										{
+																											labels: []byte("-"),
+																											children: []*trie{
+																												{
+																													labels: []byte("1"),
+																													children: []*trie{
+																														{terminal: true},
+																													},
+																												},
+																											},
+																										},
+																									},
+																								},
+																							},
+																						},
+																					},
+																				},
+																			},
+																		},
+																	},
+																},
+															},
+														},
+													},
+												},
+											},
+										},
+									},
+								},
+								{
+									labels: []byte("o"),
+									children: []*trie{
+										{
+											labels: []byte("v"),
+											children: []*trie{
+												{
+													labels: []byte("-"),
+													children: []*trie{
+														{
+															labels: []byte("ew"),
+															children: []*trie{
+																{
+																	labels: []byte("a"),
+																	children: []*trie{
+																		{
+																			labels: []byte("s"),
+																			children: []*trie{
+																				{
+																					labels: []byte("t"),
+																					children: []*trie{
+																						{
+																							labels: []byte("-"),
+																							children: []*trie{
+																								{
+																									labels: []byte("1"),
+																									children: []*trie{
+																										{terminal: true},
+																									},
+																								},
+																							},
+																						},
+																					},
+																				},
+																			},
+																		},
+																	},
+																},
+																{
+																	labels: []byte("e"),
+																	children: []*trie{
+																		{
+																			labels: []byte("s"),
+																			children: []*trie{
+																				{
+																					labels: []byte("t"),
+																					children: []*trie{
+																						{
+																							labels: []byte("-"),
+																							children: []*trie{
+																								{
+																									labels: []byte("1"),
+																									children: []*trie{
+																										{terminal: true},
+																									},
+																								},
+																							},
+																						},
+																					},
+																				},
+																			},
+																		},
+																	},
+																},
+															},
+														},
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	},
+}
+
+var catalogSuffixTrie = &trie{
+	labels: []byte("acs"),
+	children: []*trie{
+		{
+			labels: []byte("m"),
+			children: []*trie{
+				{
+					labels: []byte("a"),
+					children: []*trie{
+						{
+							labels: []byte("z"),
+							children: []*trie{
+								{
+									labels: []byte("o"),
+									children: []*trie{
+										{
+											labels: []byte("n"),
+											children: []*trie{
+												{
+													labels: []byte("a"),
+													children: []*trie{
+														{
+															labels: []byte("w"),
+															children: []*trie{
+																{
+																	labels: []byte("s"),
+																	children: []*trie{
+																		{
+																			labels: []byte("."),
+																			children: []*trie{
+																				{
+																					labels: []byte("ce"),
+																					children: []*trie{
+																						{
+																							labels: []byte("o"),
+																							children: []*trie{
+																								{
+																									labels: []byte("m"),
+																									children: []*trie{
+																										{
+																											labels: []byte("."),
+																											children: []*trie{
+																												{
+																													labels: []byte("c"),
+																													children: []*trie{
+																														{
+																															labels: []byte("n"),
+																															children: []*trie{
+																																{terminal: true},
+																															},
+																														},
+																													},
+																												},
+																											},
+																											terminal: true,
+																										},
+																									},
+																								},
+																							},
+																						},
+																						{
+																							labels: []byte("u"),
+																							children: []*trie{
+																								{terminal: true},
+																							},
+																						},
+																					},
+																				},
+																			},
+																		},
+																	},
+																},
+															},
+														},
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			labels: []byte("2ls"),
+			children: []*trie{
+				{
+					labels: []byte("s"),
+					children: []*trie{
+						{
+							labels: []byte("."),
+							children: []*trie{
+								{
+									labels: []byte("i"),
+									children: []*trie{
+										{
+											labels: []byte("c"),
+											children: []*trie{
+												{
+													labels: []byte("."),
+													children: []*trie{
+														{
+															labels: []byte("g"),
+															children: []*trie{
+																{
+																	labels: []byte("o"),
+																	children: []*trie{
+																		{
+																			labels: []byte("v"),
+																			children: []*trie{
+																				{terminal: true},
+																			},
+																		},
+																	},
+																},
+															},
+														},
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				{
+					labels: []byte("o"),
+					children: []*trie{
+						{
+							labels: []byte("u"),
+							children: []*trie{
+								{
+									labels: []byte("d"),
+									children: []*trie{
+										{
+											labels: []byte("."),
+											children: []*trie{
+												{
+													labels: []byte("a"),
+													children: []*trie{
+														{
+															labels: []byte("d"),
+															children: []*trie{
+																{
+																	labels: []byte("c"),
+																	children: []*trie{
+																		{
+																			labels: []byte("-"),
+																			children: []*trie{
+																				{
+																					labels: []byte("e"),
+																					children: []*trie{
+																						{
+																							labels: []byte("."),
+																							children: []*trie{
+																								{
+																									labels: []byte("u"),
+																									children: []*trie{
+																										{
+																											labels: []byte("k"),
+																											children: []*trie{
+																												{terminal: true},
+																											},
+																										},
+																									},
+																								},
+																							},
+																						},
+																					},
+																				},
+																			},
+																		},
+																	},
+																},
+															},
+														},
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				{
+					labels: []byte("p"),
+					children: []*trie{
+						{
+							labels: []byte("."),
+							children: []*trie{
+								{
+									labels: []byte("h"),
+									children: []*trie{
+										{
+											labels: []byte("c"),
+											children: []*trie{
+												{
+													labels: []byte("i"),
+													children: []*trie{
+														{
+															labels: []byte("."),
+															children: []*trie{
+																{
+																	labels: []byte("i"),
+																	children: []*trie{
+																		{
+																			labels: []byte("c"),
+																			children: []*trie{
+																				{
+																					labels: []byte("."),
+																					children: []*trie{
+																						{
+																							labels: []byte("g"),
+																							children: []*trie{
+																								{
+																									labels: []byte("o"),
+																									children: []*trie{
+																										{
+																											labels: []byte("v"),
+																											children: []*trie{
+																												{terminal: true},
+																											},
+																										},
+																									},
+																								},
+																							},
+																						},
+																					},
+																				},
+																			},
+																		},
+																	},
+																},
+															},
+														},
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			labels: []byte("c"),
+			children: []*trie{
+				{
+					labels: []byte("2"),
+					children: []*trie{
+						{
+							labels: []byte("s"),
+							children: []*trie{
+								{
+									labels: []byte("."),
+									children: []*trie{
+										{
+											labels: []byte("s"),
+											children: []*trie{
+												{
+													labels: []byte("g"),
+													children: []*trie{
+														{
+															labels: []byte("o"),
+															children: []*trie{
+																{
+																	labels: []byte("v"),
+																	children: []*trie{
+																		{
+																			labels: []byte("."),
+																			children: []*trie{
+																				{
+																					labels: []byte("g"),
+																					children: []*trie{
+																						{
+																							labels: []byte("o"),
+																							children: []*trie{
+																								{
+																									labels: []byte("v"),
+																									children: []*trie{
+																										{terminal: true},
+																									},
+																								},
+																							},
+																						},
+																					},
+																				},
+																			},
+																		},
+																	},
+																},
+															},
+														},
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	},
+}
@@ -0,0 +1,79 @@
+package awsmeta
+
+import (
+	"maps"
+	"reflect"
+	"runtime/debug"
+	"slices"
+	"testing"
+
+	"github.com/myerscode/aws-meta/pkg/partitions"
+	"github.com/myerscode/aws-meta/pkg/regions"
+)
+
+// TestCatalogTablesUpToDate fails when aws-meta is upgraded without running
+// go generate
+func TestCatalogTablesUpToDate(t *testing.T) {
+	const hint = "run go generate ./rules/awsmeta"
+
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		t.Fatal("no build information")
+	}
+	for _, dep := range info.Deps {
+		if dep.Path == "github.com/myerscode/aws-meta" && dep.Version != CatalogVersion {
+			t.Errorf("Catalog was generated from aws-meta %s, but %s is used: %s", CatalogVersion, dep.Version, hint)
+		}
+	}
+
+	regionList, err := regions.ListAllRegions()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	var regionIDs []string
+	for _, region := range regionList {
+		regionIDs = append(regionIDs, region.RegionId)
+	}
+	if !slices.Equal(regionIDs, catalogRegions) {
+		t.Errorf("Catalog regions differ from aws-meta: %s", hint)
+	}
+
+	partitionList, err := partitions.List()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	var want []Partition
+	for _, partition := range partitionList {
+		want = append(want, Partition{ID: partition.ID, DNSSuffix: partition.DNSSuffix})
+	}
+	if !slices.Equal(want, catalogPartitions) {
+		t.Errorf("Catalog partitions differ from aws-meta: %s", hint)
+	}
+}
+
+// TestCatalogTriesUpToDate fails when the generated prefix trees differ from
+// the ones NewMatcher builds from the tables
+func TestCatalogTriesUpToDate(t *testing.T) {
+	generated, err := NewMatcher(DefaultCatalog())
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	// Wrapping the catalog makes NewMatcher build the matcher from its tables
+	built, err := NewMatcher(struct{ Catalog }{DefaultCatalog()})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if !reflect.DeepEqual(generated.regions, built.regions) {
+		t.Error("Generated region trie differs from the catalog regions: run go generate ./rules/awsmeta")
+	}
+	if !reflect.DeepEqual(generated.suffixes, built.suffixes) {
+		t.Error("Generated DNS suffix trie differs from the catalog partitions: run go generate ./rules/awsmeta")
+	}
+	if !maps.Equal(generated.partitions, built.partitions) || generated.maxPartition != built.maxPartition {
+		t.Error("Generated partition IDs differ from the catalog partitions: run go generate ./rules/awsmeta")
+	}
+	if generated.zoneSuffix.String() != built.zoneSuffix.String() {
+		t.Errorf("Expected zone suffix %q, got %q", built.zoneSuffix, generated.zoneSuffix)
+	}
+}
@@ -0,0 +1,168 @@
+// Command gencatalog writes the tables of the default catalog from the
+// regions and partitions of github.com/myerscode/aws-meta, along with the
+// prefix trees of its matcher, so nothing is built from them at runtime. It
+// is run by go generate in the awsmeta package:
+//
+//	go generate ./rules/awsmeta
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"runtime/debug"
+	"strconv"
+
+	"github.com/myerscode/aws-meta/pkg/partitions"
+	"github.com/myerscode/aws-meta/pkg/regions"
+)
+
+const modulePath = "github.com/myerscode/aws-meta"
+
+func main() {
+	output := flag.String("output", "catalog_tables.go", "file to write the tables to")
+	flag.Parse()
+
+	src, err := generate()
+	if err != nil {
+		log.Fatalf("gencatalog: %s", err)
+	}
+	if err := os.WriteFile(*output, src, 0o644); err != nil {
+		log.Fatalf("gencatalog: %s", err)
+	}
+}
+
+func generate() ([]byte, error) {
+	version, err := moduleVersion()
+	if err != nil {
+		return nil, err
+	}
+
+	regionList, err := regions.ListAllRegions()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS regions: %w", err)
+	}
+	if len(regionList) == 0 {
+		return nil, errors.New("AWS region list is empty")
+	}
+	partitionList, err := partitions.List()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS partitions: %w", err)
+	}
+	if len(partitionList) == 0 {
+		return nil, errors.New("AWS partition list is empty")
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by gencatalog from %s %s. DO NOT EDIT.\n\n", modulePath, version)
+	fmt.Fprintf(&buf, "package awsmeta\n\n")
+	fmt.Fprintf(&buf, "// CatalogVersion is the version of %s the default\n", modulePath)
+	fmt.Fprintf(&buf, "// catalog was generated from\n")
+	fmt.Fprintf(&buf, "const CatalogVersion = %s\n\n", strconv.Quote(version))
+
+	fmt.Fprintf(&buf, "var catalogRegions = []string{\n")
+	for _, region := range regionList {
+		fmt.Fprintf(&buf, "%s, // %s\n", strconv.Quote(region.RegionId), region.RegionName)
+	}
+	fmt.Fprintf(&buf, "}\n\n")
+
+	fmt.Fprintf(&buf, "var catalogPartitions = []Partition{\n")
+	for _, partition := range partitionList {
+		fmt.Fprintf(&buf, "{ID: %s, DNSSuffix: %s},\n", strconv.Quote(partition.ID), strconv.Quote(partition.DNSSuffix))
+	}
+	fmt.Fprintf(&buf, "}\n\n")
+
+	// The prefix trees are built the way awsmeta.NewMatcher builds them, so
+	// the default matcher is the same as one built from the tables
+	regionTrie, suffixTrie := &trie{}, &trie{}
+	for _, region := range regionList {
+		regionTrie.add(region.RegionId)
+	}
+	maxPartition := 0
+	fmt.Fprintf(&buf, "var catalogPartitionIDs = map[string]bool{\n")
+	for _, partition := range partitionList {
+		fmt.Fprintf(&buf, "%s: true,\n", strconv.Quote(partition.ID))
+		maxPartition = max(maxPartition, len(partition.ID))
+		if partition.DNSSuffix != "" {
+			suffixTrie.add(partition.DNSSuffix)
+		}
+	}
+	fmt.Fprintf(&buf, "}\n\n")
+	fmt.Fprintf(&buf, "const catalogMaxPartition = %d\n\n", maxPartition)
+
+	fmt.Fprintf(&buf, "var catalogRegionTrie = &trie")
+	regionTrie.write(&buf)
+	fmt.Fprintf(&buf, "\n\nvar catalogSuffixTrie = &trie")
+	suffixTrie.write(&buf)
+	fmt.Fprintf(&buf, "\n")
+
+	return format.Source(buf.Bytes())
+}
+
+// trie mirrors the prefix tree of awsmeta, which is written out as a literal
+type trie struct {
+	labels   []byte
+	children []*trie
+	terminal bool
+}
+
+func (t *trie) add(code string) {
+	node := t
+	for i := 0; i < len(code); i++ {
+		var child *trie
+		for j, label := range node.labels {
+			if label == code[i] {
+				child = node.children[j]
+			}
+		}
+		if child == nil {
+			child = &trie{}
+			node.labels = append(node.labels, code[i])
+			node.children = append(node.children, child)
+		}
+		node = child
+	}
+	node.terminal = true
+}
+
+// write writes the composite literal of the node, without its type
+func (t *trie) write(buf *bytes.Buffer) {
+	fmt.Fprintf(buf, "{")
+	if len(t.labels) > 0 {
+		fmt.Fprintf(buf, "\nlabels: []byte(%s),\nchildren: []*trie{\n", strconv.Quote(string(t.labels)))
+		for _, child := range t.children {
+			child.write(buf)
+			fmt.Fprintf(buf, ",\n")
+		}
+		fmt.Fprintf(buf, "},\n")
+	}
+	if t.terminal {
+		fmt.Fprintf(buf, "terminal: true")
+		if len(t.labels) > 0 {
+			fmt.Fprintf(buf, ",\n")
+		}
+	}
+	fmt.Fprintf(buf, "}")
+}
+
+// moduleVersion returns the version of aws-meta this command was built with
+func moduleVersion() (string, error) {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "", errors.New("no build information")
+	}
+	for _, dep := range info.Deps {
+		if dep.Path != modulePath {
+			continue
+		}
+		if dep.Replace != nil {
+			return dep.Replace.Version, nil
+		}
+		return dep.Version, nil
+	}
+	return "", fmt.Errorf("%s is not a dependency", modulePath)
+}
@@ -0,0 +1,370 @@
+package awsmeta
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// HitKind is the kind of value found by a Matcher
+type HitKind int
+
+const (
+	// HitRegion is a region code, such as "eu-west-1"
+	HitRegion HitKind = iota
+
+	// HitAvailabilityZone is a region code followed by an availability zone
+	// suffix, such as "eu-west-1a". The region is also reported on its own.
+	HitAvailabilityZone
+
+	// HitPartition is the partition field of an ARN, such as "aws" in
+	// "arn:aws:s3:::bucket"
+	HitPartition
+
+	// HitServicePrincipal is a service name followed by a DNS suffix, such as
+	// "s3.amazonaws.com" or "lambda.amazonaws.com.cn"
+	HitServicePrincipal
+
+	// HitAccountID is a 12-digit account ID
+	HitAccountID
+
+	// HitAMIID is an AMI ID, such as "ami-0abcdef1234567890"
+	HitAMIID
+)
+
+// Hit is a value found in a text by a Matcher
+type Hit struct {
+	Kind  HitKind
+	Value string
+
+	// Start and End are the byte range of Value in the text
+	Start int
+	End   int
+
+	// InARN is set on regions written in the region field of an ARN
+	InARN bool
+
+	// Service is the service name of a service principal, such as "s3"
+	Service string
+}
+
+// Matcher finds the regions, availability zones, partitions, service
+// principals, account IDs and AMI IDs of a text in a single pass, looking up
+// the codes of its catalog in prefix trees rather than regex alternations
+type Matcher struct {
+	regions      *trie
+	suffixes     *trie
+	partitions   map[string]bool
+	maxPartition int
+	zoneSuffix   *regexp.Regexp
+
+	// The hits of the last text are kept, as the detectors of every rule
+	// look at the same text in turn
+	mu   sync.Mutex
+	text string
+	hits []Hit
+}
+
+// NewMatcher builds a matcher for the regions and partitions of the catalog.
+// A catalog that fails to load or lists no regions or partitions is reported
+// as an error. The prefix trees of the default catalog are generated with its
+// tables, so its matcher is not built at runtime.
+func NewMatcher(catalog Catalog) (*Matcher, error) {
+	if _, ok := catalog.(embeddedCatalog); ok {
+		return &Matcher{
+			regions:      catalogRegionTrie,
+			suffixes:     catalogSuffixTrie,
+			partitions:   catalogPartitionIDs,
+			maxPartition: catalogMaxPartition,
+			zoneSuffix:   embeddedZoneSuffix,
+		}, nil
+	}
+
+	regionList, err := catalog.Regions()
+	if err != nil {
+		return nil, err
+	}
+	if len(regionList) == 0 {
+		return nil, errors.New("AWS region list is empty")
+	}
+	partitionList, err := catalog.Partitions()
+	if err != nil {
+		return nil, err
+	}
+	if len(partitionList) == 0 {
+		return nil, errors.New("AWS partition list is empty")
+	}
+
+	zoneSuffix, err := compileZoneSuffix(catalog.AvailabilityZoneSuffix())
+	if err != nil {
+		return nil, fmt.Errorf("invalid availability zone suffix: %w", err)
+	}
+
+	m := &Matcher{
+		regions:    newTrie(),
+		suffixes:   newTrie(),
+		partitions: make(map[string]bool),
+		zoneSuffix: zoneSuffix,
+	}
+	for _, region := range regionList {
+		m.regions.add(region)
+	}
+	for _, partition := range partitionList {
+		m.partitions[partition.ID] = true
+		m.maxPartition = max(m.maxPartition, len(partition.ID))
+		if partition.DNSSuffix != "" {
+			m.suffixes.add(partition.DNSSuffix)
+		}
+	}
+	if m.suffixes.empty() {
+		return nil, errors.New("AWS partitions have no DNS suffixes")
+	}
+
+	return m, nil
+}
+
+// compileZoneSuffix compiles the availability zone suffix of a catalog to
+// match the longest suffix at the start of a text
+func compileZoneSuffix(suffix string) (*regexp.Regexp, error) {
+	zoneSuffix, err := regexp.Compile(fmt.Sprintf("^(?:%s)", suffix))
+	if err != nil {
+		return nil, err
+	}
+	zoneSuffix.Longest()
+	return zoneSuffix, nil
+}
+
+// Find returns the hits in the text, ordered by their start. Hits of the
+// same kind don't overlap. The returned slice is shared and must not be
+// modified.
+func (m *Matcher) Find(text string) []Hit {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.hits != nil && m.text == text {
+		return m.hits
+	}
+	m.text, m.hits = text, m.find(text)
+	return m.hits
+}
+
+// First returns the first hit of the kind in the text
+func (m *Matcher) First(text string, kind HitKind) (Hit, bool) {
+	for _, hit := range m.Find(text) {
+		if hit.Kind == kind {
+			return hit, true
+		}
+	}
+	return Hit{}, false
+}
+
+// Is reports whether the whole value is a hit of the kind, such as a value
+// that is a region code and nothing else
+func (m *Matcher) Is(value string, kind HitKind) bool {
+	hit, ok := m.First(value, kind)
+	return ok && hit.Start == 0 && hit.End == len(value)
+}
+
+func (m *Matcher) find(text string) []Hit {
+	hits := []Hit{}
+	var arnRegions map[int]bool
+	regionsFrom, principalsFrom := 0, 0
+
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+
+		if c == 'a' && strings.HasPrefix(text[i:], "arn:") {
+			if start, end, ok := m.arnPartition(text, i); ok {
+				hits = append(hits, Hit{Kind: HitPartition, Value: text[start:end], Start: start, End: end})
+			}
+			if start, ok := m.arnRegion(text, i); ok {
+				if arnRegions == nil {
+					arnRegions = make(map[int]bool)
+				}
+				arnRegions[start] = true
+			}
+		}
+
+		if i >= regionsFrom {
+			if end := m.regions.match(text, i); end > 0 {
+				hits = append(hits, Hit{Kind: HitRegion, Value: text[i:end], Start: i, End: end, InARN: arnRegions[i]})
+				if loc := m.zoneSuffix.FindStringIndex(text[end:]); loc != nil && loc[1] > 0 {
+					hits = append(hits, Hit{Kind: HitAvailabilityZone, Value: text[i : end+loc[1]], Start: i, End: end + loc[1]})
+				}
+				regionsFrom = end
+			}
+		}
+
+		if c == '.' && i > principalsFrom {
+			if end := m.suffixes.match(text, i+1); end > 0 {
+				start := i
+				for start > principalsFrom && isServiceByte(text[start-1]) {
+					start--
+				}
+				if start < i {
+					hits = append(hits, Hit{Kind: HitServicePrincipal, Value: text[start:end], Start: start, End: end, Service: text[start:i]})
+					principalsFrom = end
+				}
+			}
+		}
+
+		if i > 0 && isRegexpWordByte(text[i-1]) {
+			continue
+		}
+
+		switch {
+		case isDigit(c):
+			end := i
+			for end < len(text) && isDigit(text[end]) {
+				end++
+			}
+			if end-i == 12 && (end == len(text) || !isRegexpWordByte(text[end])) {
+				hits = append(hits, Hit{Kind: HitAccountID, Value: text[i:end], Start: i, End: end})
+			}
+		case c == 'a' && strings.HasPrefix(text[i:], "ami-"):
+			end := i + len("ami-")
+			for end < len(text) && isHexByte(text[end]) {
+				end++
+			}
+			if n := end - i - len("ami-"); n >= 8 && n <= 17 && (end == len(text) || !isRegexpWordByte(text[end])) {
+				hits = append(hits, Hit{Kind: HitAMIID, Value: text[i:end], Start: i, End: end})
+			}
+		}
+	}
+
+	slices.SortStableFunc(hits, func(a, b Hit) int { return a.Start - b.Start })
+	return hits
+}
+
+// arnPartition returns the range of the partition field of the ARN at i, if
+// it is a known partition
+func (m *Matcher) arnPartition(text string, i int) (int, int, bool) {
+	start := i + len("arn:")
+	field := text[start:min(len(text), start+m.maxPartition+1)]
+	n := strings.IndexByte(field, ':')
+	if n < 0 || !m.partitions[field[:n]] {
+		return 0, 0, false
+	}
+	return start, start + n, true
+}
+
+// arnRegion returns the start of the region field of the ARN at i, if the
+// ARN is in an "aws" partition, or one written by a template interpolation,
+// and the field is a known region
+func (m *Matcher) arnRegion(text string, i int) (int, bool) {
+	pos := i + len("arn:")
+	if !strings.HasPrefix(text[pos:], "aws") && !strings.HasPrefix(text[pos:], "${") {
+		return 0, false
+	}
+	for field := 0; field < 2; field++ {
+		n := strings.IndexByte(text[pos:], ':')
+		if n < 0 || (field == 1 && n == 0) {
+			return 0, false
+		}
+		pos += n + 1
+	}
+
+	end := pos + strings.IndexByte(text[pos:], ':')
+	if end < pos || m.regions.match(text, pos) != end {
+		return 0, false
+	}
+	return pos, true
+}
+
+// DNSSuffixAt returns the DNS suffix text[i:] starts with, such as the
+// "amazonaws.com" of a service principal whose service name is not known
+func (m *Matcher) DNSSuffixAt(text string, i int) (string, bool) {
+	if end := m.suffixes.match(text, i); end > 0 {
+		return text[i:end], true
+	}
+	return "", false
+}
+
+// IsAccountID reports whether the value is a 12-digit account ID
+func IsAccountID(value string) bool {
+	if len(value) != 12 {
+		return false
+	}
+	for i := 0; i < len(value); i++ {
+		if !isDigit(value[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// trie is a prefix tree of codes, such as region codes or DNS suffixes. Nodes
+// have few children, which are found faster in a slice than in a map.
+type trie struct {
+	labels   []byte
+	children []*trie
+	terminal bool
+}
+
+func newTrie() *trie {
+	return &trie{}
+}
+
+func (t *trie) add(code string) {
+	node := t
+	for i := 0; i < len(code); i++ {
+		child := node.child(code[i])
+		if child == nil {
+			child = newTrie()
+			node.labels = append(node.labels, code[i])
+			node.children = append(node.children, child)
+		}
+		node = child
+	}
+	node.terminal = true
+}
+
+func (t *trie) child(c byte) *trie {
+	for i, label := range t.labels {
+		if label == c {
+			return t.children[i]
+		}
+	}
+	return nil
+}
+
+func (t *trie) empty() bool {
+	return len(t.children) == 0 && !t.terminal
+}
+
+// match returns the end of the longest code starting at text[i], or 0 when
+// no code starts there
+func (t *trie) match(text string, i int) int {
+	end := 0
+	node := t
+	for j := i; j < len(text); j++ {
+		node = node.child(text[j])
+		if node == nil {
+			break
+		}
+		if node.terminal {
+			end = j + 1
+		}
+	}
+	return end
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+func isHexByte(c byte) bool {
+	return isDigit(c) || ('a' <= c && c <= 'f')
+}
+
+func isServiceByte(c byte) bool {
+	return c == '-' || isDigit(c) || ('a' <= c && c <= 'z')
+}
+
+// isRegexpWordByte reports whether c is a word character in the sense of \b
+func isRegexpWordByte(c byte) bool {
+	return c == '_' || isDigit(c) || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
@@ -0,0 +1,318 @@
+package awsmeta
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestMatcherRegion(t *testing.T) {
+	matcher := defaultMatcher(t)
+
+	// Test some known regions
+	testCases := []struct {
+		region   string
+		expected bool
+	}{
+		{"us-east-1", true},
+		{"us-west-2", true},
+		{"eu-west-1", true},
+		{"ap-southeast-1", true},
+		{"invalid-region", false},
+		{"", false},
+	}
+
+	for _, tc := range testCases {
+		result := matcher.Is(tc.region, HitRegion)
+		if result != tc.expected {
+			t.Errorf("Region %s: expected %v, got %v", tc.region, tc.expected, result)
+		}
+	}
+}
+
+func TestMatcherAvailabilityZone(t *testing.T) {
+	matcher := defaultMatcher(t)
+
+	// Test some known AZs
+	testCases := []struct {
+		az       string
+		expected bool
+	}{
+		{"us-east-1a", true},
+		{"us-west-2b", true},
+		{"eu-west-1c", true},
+		{"us-east-1", false}, // Not an AZ, just a region
+		{"invalid-az", false},
+	}
+
+	for _, tc := range testCases {
+		result := matcher.Is(tc.az, HitAvailabilityZone)
+		if result != tc.expected {
+			t.Errorf("AZ %s: expected %v, got %v", tc.az, tc.expected, result)
+		}
+	}
+}
+
+func TestMatcherARNRegion(t *testing.T) {
+	matcher := defaultMatcher(t)
+
+	// Test ARNs with regions
+	testCases := []struct {
+		arn      string
+		expected bool
+	}{
+		{"arn:aws:s3:us-east-1:123456789012:bucket/my-bucket", true},
+		{"arn:aws:ec2:eu-west-1:123456789012:instance/i-1234567890abcdef0", true},
+		{"arn:aws:iam::123456789012:role/my-role", false}, // No region in IAM ARN
+		{"arn:aws:s3:::us-east-1:bucket", false},          // Region outside the region field
+		{"arn:${}:sqs:eu-west-1:${}:jobs", true},          // Partition from a template interpolation
+		{"not-an-arn", false},
+	}
+
+	for _, tc := range testCases {
+		hit, ok := matcher.First(tc.arn, HitRegion)
+		result := ok && hit.InARN
+		if result != tc.expected {
+			t.Errorf("ARN %s: expected %v, got %v", tc.arn, tc.expected, result)
+		}
+	}
+}
+
+func TestMatcherPartition(t *testing.T) {
+	matcher := defaultMatcher(t)
+
+	// Test ARNs with different partitions
+	testCases := []struct {
+		arn      string
+		expected bool
+	}{
+		{"arn:aws:s3:us-east-1:123456789012:bucket/my-bucket", true},
+		{"arn:aws-cn:s3:cn-north-1:123456789012:bucket/my-bucket", true},
+		{"arn:aws-us-gov:s3:us-gov-west-1:123456789012:bucket/my-bucket", true},
+		{"arn:aws-moon:s3:::bucket", false},
+		{"not-an-arn", false},
+	}
+
+	for _, tc := range testCases {
+		_, result := matcher.First(tc.arn, HitPartition)
+		if result != tc.expected {
+			t.Errorf("ARN %s: expected %v, got %v", tc.arn, tc.expected, result)
+		}
+	}
+}
+
+func TestMatcherRegionInString(t *testing.T) {
+	matcher := defaultMatcher(t)
+
+	// Test finding regions within strings
+	testCases := []struct {
+		text     string
+		expected bool
+	}{
+		{"The bucket is in us-east-1", true},
+		{"Deploying to eu-west-1 region", true},
+		{"No region here", false},
+		{"", false},
+	}
+
+	for _, tc := range testCases {
+		_, result := matcher.First(tc.text, HitRegion)
+		if result != tc.expected {
+			t.Errorf("Text %q: expected %v, got %v", tc.text, tc.expected, result)
+		}
+	}
+}
+
+func TestMatcherServicePrincipal(t *testing.T) {
+	matcher := defaultMatcher(t)
+
+	testCases := []struct {
+		value    string
+		expected bool
+	}{
+		{"s3.amazonaws.com", true},
+		{"lambda.amazonaws.com", true},
+		{"ec2.amazonaws.com.cn", true},
+		{"ecs-tasks.amazonaws.com", true},
+		{"lambda.c2s.ic.gov", true},
+		{"s3.sc2s.sgov.gov", true},
+		{"lambda.amazonaws.eu", true},
+		{"s3.cloud.adc-e.uk", true},
+		{"not-a-service-principal", false},
+		{"amazonaws.com", false}, // No service name prefix
+		{"", false},
+	}
+
+	for _, tc := range testCases {
+		_, result := matcher.First(tc.value, HitServicePrincipal)
+		if result != tc.expected {
+			t.Errorf("Value %q: expected %v, got %v", tc.value, tc.expected, result)
+		}
+	}
+}
+
+func TestMatcherDNSSuffixAt(t *testing.T) {
+	matcher := defaultMatcher(t)
+
+	testCases := []struct {
+		text     string
+		i        int
+		expected string
+	}{
+		{"${}.amazonaws.com", 4, "amazonaws.com"},
+		{"${}.amazonaws.com.cn", 4, "amazonaws.com.cn"},
+		{"${}.example.com", 4, ""},
+		{"s3.amazonaws.com", 0, ""},
+	}
+
+	for _, tc := range testCases {
+		suffix, _ := matcher.DNSSuffixAt(tc.text, tc.i)
+		if suffix != tc.expected {
+			t.Errorf("Text %q at %d: expected %q, got %q", tc.text, tc.i, tc.expected, suffix)
+		}
+	}
+}
+
+func TestMatcherFind(t *testing.T) {
+	matcher := defaultMatcher(t)
+
+	text := `{"Resource": "arn:aws:sqs:eu-west-2:123456789012:jobs", "Service": "lambda.amazonaws.com.cn", "Zone": "eu-west-2b", "Image": "ami-0abcdef1234567890"}`
+	want := []Hit{
+		{Kind: HitPartition, Value: "aws", Start: 18, End: 21},
+		{Kind: HitRegion, Value: "eu-west-2", Start: 26, End: 35, InARN: true},
+		{Kind: HitAccountID, Value: "123456789012", Start: 36, End: 48},
+		{Kind: HitServicePrincipal, Value: "lambda.amazonaws.com.cn", Start: 68, End: 91, Service: "lambda"},
+		{Kind: HitRegion, Value: "eu-west-2", Start: 103, End: 112},
+		{Kind: HitAvailabilityZone, Value: "eu-west-2b", Start: 103, End: 113},
+		{Kind: HitAMIID, Value: "ami-0abcdef1234567890", Start: 126, End: 147},
+	}
+
+	if got := matcher.Find(text); !slices.Equal(got, want) {
+		t.Errorf("Find() =\n%v\nwant\n%v", got, want)
+	}
+}
+
+func TestMatcherIDs(t *testing.T) {
+	matcher := defaultMatcher(t)
+
+	testCases := []struct {
+		value string
+		kind  HitKind
+		found bool
+	}{
+		{"123456789012", HitAccountID, true},
+		{"account 123456789012 here", HitAccountID, true},
+		{"1234567890123", HitAccountID, false}, // 13 digits
+		{"x123456789012", HitAccountID, false},
+		{"ami-12345678", HitAMIID, true},
+		{"ami-0abcdef1234567890", HitAMIID, true},
+		{"ami-1234567", HitAMIID, false},            // Too short
+		{"ami-0abcdef12345678901", HitAMIID, false}, // Too long
+		{"my-ami-12345678", HitAMIID, true},         // "-" is not a word character
+		{"xami-12345678", HitAMIID, false},
+	}
+
+	for _, tc := range testCases {
+		if _, found := matcher.First(tc.value, tc.kind); found != tc.found {
+			t.Errorf("Value %q: expected %v, got %v", tc.value, tc.found, found)
+		}
+	}
+}
+
+func TestIsAccountID(t *testing.T) {
+	if !IsAccountID("123456789012") || IsAccountID("12345678901") || IsAccountID("12345678901a") {
+		t.Error("Expected only 12-digit values to be account IDs")
+	}
+}
+
+func TestNewMatcherCatalog(t *testing.T) {
+	matcher, err := NewMatcher(testCatalog{
+		regions:    []string{"mars-north-1"},
+		partitions: []Partition{{ID: "aws-mars", DNSSuffix: "amazonaws.mars"}},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if !matcher.Is("mars-north-1", HitRegion) || matcher.Is("us-east-1", HitRegion) {
+		t.Error("Expected only the regions of the catalog to match")
+	}
+	if !matcher.Is("mars-north-1a", HitAvailabilityZone) {
+		t.Error("Expected availability zones of the catalog regions to match")
+	}
+	if _, ok := matcher.First("arn:aws-mars:s3:::bucket", HitPartition); !ok {
+		t.Error("Expected the partitions of the catalog to match")
+	}
+	if _, ok := matcher.First("arn:aws:s3:::bucket", HitPartition); ok {
+		t.Error("Expected only the partitions of the catalog to match")
+	}
+	if _, ok := matcher.First("lambda.amazonaws.mars", HitServicePrincipal); !ok {
+		t.Error("Expected the DNS suffixes of the catalog to match")
+	}
+}
+
+func TestNewMatcherErrors(t *testing.T) {
+	partitions := []Partition{{ID: "aws", DNSSuffix: "amazonaws.com"}}
+	testCases := []struct {
+		name    string
+		catalog Catalog
+	}{
+		{"regions fail to load", testCatalog{regionsErr: errors.New("broken"), partitions: partitions}},
+		{"no regions", testCatalog{partitions: partitions}},
+		{"no partitions", testCatalog{regions: []string{"us-east-1"}}},
+		{"no DNS suffixes", testCatalog{regions: []string{"us-east-1"}, partitions: []Partition{{ID: "aws"}}}},
+		{"invalid availability zone suffix", testCatalog{regions: []string{"us-east-1"}, partitions: partitions, azSuffix: "["}},
+	}
+
+	for _, tc := range testCases {
+		if _, err := NewMatcher(tc.catalog); err == nil {
+			t.Errorf("%s: expected an error", tc.name)
+		}
+	}
+}
+
+// testCatalog is a catalog with fixed contents
+type testCatalog struct {
+	regions    []string
+	regionsErr error
+	partitions []Partition
+	azSuffix   string
+}
+
+func (c testCatalog) Regions() ([]string, error) {
+	return c.regions, c.regionsErr
+}
+
+func (c testCatalog) AvailabilityZoneSuffix() string {
+	if c.azSuffix == "" {
+		return "[a-z]"
+	}
+	return c.azSuffix
+}
+
+func (c testCatalog) Partitions() ([]Partition, error) {
+	return c.partitions, nil
+}
+
+func defaultMatcher(t *testing.T) *Matcher {
+	t.Helper()
+
+	matcher, err := NewMatcher(DefaultCatalog())
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	return matcher
+}
+
+func BenchmarkMatcherFind(b *testing.B) {
+	matcher, err := NewMatcher(DefaultCatalog())
+	if err != nil {
+		b.Fatal(err)
+	}
+	text := `{"Statement": [{"Effect": "Allow", "Principal": {"Service": "lambda.amazonaws.com"}, "Resource": ["arn:aws:sqs:eu-west-2:123456789012:jobs", "arn:aws:s3:::my-bucket/*"]}]}`
+
+	for b.Loop() {
+		// find skips the cache of the last text
+		matcher.find(text)
+	}
+}
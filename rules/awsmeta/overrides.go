@@ -0,0 +1,88 @@
+package awsmeta
+
+import (
+	"slices"
+
+	"github.com/hashicorp/hcl/v2/hclsimple"
+)
+
+// CatalogOverrides describe regions and partitions a catalog doesn't know
+// about yet, such as newly launched or private ones
+type CatalogOverrides struct {
+	// Regions are region codes added to the catalog
+	Regions []string `hcl:"regions,optional"`
+
+	// Partitions are added to the catalog, or replace the partition of the
+	// same ID
+	Partitions []PartitionOverride `hcl:"partition,block"`
+}
+
+// PartitionOverride is a "partition" block of an overrides file
+type PartitionOverride struct {
+	ID        string `hcl:"id,label"`
+	DNSSuffix string `hcl:"dns_suffix"`
+}
+
+// LoadCatalogOverrides reads overrides from an HCL (.hcl) or JSON (.json) file:
+//
+//	regions = ["example-east-1"]
+//
+//	partition "aws-example" {
+//	  dns_suffix = "amazonaws.example"
+//	}
+func LoadCatalogOverrides(filename string) (*CatalogOverrides, error) {
+	overrides := &CatalogOverrides{}
+	if err := hclsimple.DecodeFile(filename, nil, overrides); err != nil {
+		return nil, err
+	}
+	return overrides, nil
+}
+
+// MergeCatalog returns a catalog listing the regions and partitions of the
+// base catalog along with the overrides
+func MergeCatalog(base Catalog, overrides *CatalogOverrides) Catalog {
+	return mergedCatalog{base: base, overrides: overrides}
+}
+
+type mergedCatalog struct {
+	base      Catalog
+	overrides *CatalogOverrides
+}
+
+func (c mergedCatalog) Regions() ([]string, error) {
+	regionList, err := c.base.Regions()
+	if err != nil {
+		return nil, err
+	}
+
+	regionList = slices.Clone(regionList)
+	for _, region := range c.overrides.Regions {
+		if !slices.Contains(regionList, region) {
+			regionList = append(regionList, region)
+		}
+	}
+	return regionList, nil
+}
+
+func (c mergedCatalog) AvailabilityZoneSuffix() string {
+	return c.base.AvailabilityZoneSuffix()
+}
+
+func (c mergedCatalog) Partitions() ([]Partition, error) {
+	partitionList, err := c.base.Partitions()
+	if err != nil {
+		return nil, err
+	}
+
+	partitionList = slices.Clone(partitionList)
+	for _, override := range c.overrides.Partitions {
+		partition := Partition{ID: override.ID, DNSSuffix: override.DNSSuffix}
+		i := slices.IndexFunc(partitionList, func(p Partition) bool { return p.ID == override.ID })
+		if i < 0 {
+			partitionList = append(partitionList, partition)
+		} else {
+			partitionList[i] = partition
+		}
+	}
+	return partitionList, nil
+}
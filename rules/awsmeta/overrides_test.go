@@ -0,0 +1,112 @@
+package awsmeta
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestLoadCatalogOverrides(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"overrides.hcl": `
+regions = ["example-east-1"]
+
+partition "aws-example" {
+  dns_suffix = "amazonaws.example"
+}`,
+		"overrides.json": `{
+  "regions": ["example-east-1"],
+  "partition": {
+    "aws-example": {"dns_suffix": "amazonaws.example"}
+  }
+}`,
+	}
+
+	for name, content := range files {
+		t.Run(name, func(t *testing.T) {
+			filename := filepath.Join(dir, name)
+			if err := os.WriteFile(filename, []byte(content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			overrides, err := LoadCatalogOverrides(filename)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if !slices.Equal(overrides.Regions, []string{"example-east-1"}) {
+				t.Errorf("Regions = %v", overrides.Regions)
+			}
+			if !slices.Equal(overrides.Partitions, []PartitionOverride{{ID: "aws-example", DNSSuffix: "amazonaws.example"}}) {
+				t.Errorf("Partitions = %v", overrides.Partitions)
+			}
+		})
+	}
+}
+
+func TestLoadCatalogOverridesErrors(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"missing.hcl":     "",
+		"overrides.yaml":  "regions: []",
+		"invalid.hcl":     `regions = "example-east-1"`,
+		"no_suffix.hcl":   `partition "aws-example" {}`,
+		"unknown_key.hcl": `zones = []`,
+	}
+
+	for name, content := range files {
+		filename := filepath.Join(dir, name)
+		if name != "missing.hcl" {
+			if err := os.WriteFile(filename, []byte(content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if _, err := LoadCatalogOverrides(filename); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestMergeCatalog(t *testing.T) {
+	base := testCatalog{
+		regions: []string{"us-east-1", "eu-west-1"},
+		partitions: []Partition{
+			{ID: "aws", DNSSuffix: "amazonaws.com"},
+			{ID: "aws-iso", DNSSuffix: "c2s.ic.gov"},
+		},
+	}
+	catalog := MergeCatalog(base, &CatalogOverrides{
+		Regions: []string{"eu-west-1", "example-east-1"},
+		Partitions: []PartitionOverride{
+			{ID: "aws-iso", DNSSuffix: "c2s.example"},
+			{ID: "aws-example", DNSSuffix: "amazonaws.example"},
+		},
+	})
+
+	regions, err := catalog.Regions()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if want := []string{"us-east-1", "eu-west-1", "example-east-1"}; !slices.Equal(regions, want) {
+		t.Errorf("Regions() = %v, want %v", regions, want)
+	}
+
+	partitions, err := catalog.Partitions()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	want := []Partition{
+		{ID: "aws", DNSSuffix: "amazonaws.com"},
+		{ID: "aws-iso", DNSSuffix: "c2s.example"},
+		{ID: "aws-example", DNSSuffix: "amazonaws.example"},
+	}
+	if !slices.Equal(partitions, want) {
+		t.Errorf("Partitions() = %v, want %v", partitions, want)
+	}
+
+	// The base catalog is left as it was
+	if len(base.regions) != 2 || base.partitions[1].DNSSuffix != "c2s.ic.gov" {
+		t.Error("Expected the base catalog to be unchanged")
+	}
+}
@@ -0,0 +1,526 @@
+package awsmeta
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// PolicyString is a value of an IAM policy document. Start and End are the
+// byte range of its JSON token in the document text, quotes included.
+// Numbers and booleans, which appear in conditions, keep their raw text.
+type PolicyString struct {
+	Value string
+	Start int
+	End   int
+}
+
+// Policy is an IAM policy document
+type Policy struct {
+	Version   *PolicyString
+	ID        *PolicyString
+	Statement []*PolicyStatement
+}
+
+// PolicyStatement is a statement of an IAM policy document
+type PolicyStatement struct {
+	Sid          *PolicyString
+	Effect       *PolicyString
+	Principal    []*PolicyPrincipal
+	NotPrincipal []*PolicyPrincipal
+	Action       []*PolicyString
+	NotAction    []*PolicyString
+	Resource     []*PolicyString
+	NotResource  []*PolicyString
+	Condition    []*PolicyCondition
+}
+
+// PolicyPrincipal holds the principals of one type, such as "AWS" or
+// "Service". A wildcard principal ("Principal": "*") has the type "*".
+type PolicyPrincipal struct {
+	Type   string
+	Values []*PolicyString
+}
+
+// PolicyCondition holds the values tested by one condition key
+type PolicyCondition struct {
+	Operator string
+	Key      string
+	Values   []*PolicyString
+}
+
+// ParsePolicy parses the text of an IAM policy document. Statement, Action,
+// Resource and condition values may be written as a single value or a list.
+// Unknown elements are ignored.
+//
+// The text may be a template. A ${...} placeholder is read as an opaque value
+// wherever a value may appear, is skipped where an object member may appear,
+// and is kept as text within strings, so the structure around it is still
+// recovered.
+func ParsePolicy(text string) (*Policy, error) {
+	root, err := parseJSON(text)
+	if err != nil {
+		return nil, err
+	}
+	if root.kind != jsonObject {
+		return nil, fmt.Errorf("policy is not a JSON object")
+	}
+
+	policy := &Policy{}
+	for i, key := range root.keys {
+		value := root.values[i]
+		switch key {
+		case "Version":
+			policy.Version = value.policyString()
+		case "Id":
+			policy.ID = value.policyString()
+		case "Statement":
+			for _, node := range value.list() {
+				if node.kind == jsonObject {
+					policy.Statement = append(policy.Statement, parseStatement(node))
+				}
+			}
+		}
+	}
+
+	return policy, nil
+}
+
+func parseStatement(node *jsonNode) *PolicyStatement {
+	statement := &PolicyStatement{}
+	for i, key := range node.keys {
+		value := node.values[i]
+		switch key {
+		case "Sid":
+			statement.Sid = value.policyString()
+		case "Effect":
+			statement.Effect = value.policyString()
+		case "Principal":
+			statement.Principal = parsePrincipal(value)
+		case "NotPrincipal":
+			statement.NotPrincipal = parsePrincipal(value)
+		case "Action":
+			statement.Action = value.policyStrings()
+		case "NotAction":
+			statement.NotAction = value.policyStrings()
+		case "Resource":
+			statement.Resource = value.policyStrings()
+		case "NotResource":
+			statement.NotResource = value.policyStrings()
+		case "Condition":
+			statement.Condition = parseCondition(value)
+		}
+	}
+	return statement
+}
+
+func parsePrincipal(node *jsonNode) []*PolicyPrincipal {
+	if node.kind == jsonString {
+		return []*PolicyPrincipal{{Type: node.str, Values: node.policyStrings()}}
+	}
+
+	var principals []*PolicyPrincipal
+	if node.kind == jsonObject {
+		for i, key := range node.keys {
+			principals = append(principals, &PolicyPrincipal{Type: key, Values: node.values[i].policyStrings()})
+		}
+	}
+	return principals
+}
+
+func parseCondition(node *jsonNode) []*PolicyCondition {
+	var conditions []*PolicyCondition
+	if node.kind != jsonObject {
+		return conditions
+	}
+
+	for i, operator := range node.keys {
+		keys := node.values[i]
+		if keys.kind != jsonObject {
+			continue
+		}
+		for j, key := range keys.keys {
+			conditions = append(conditions, &PolicyCondition{
+				Operator: operator,
+				Key:      key,
+				Values:   keys.values[j].policyStrings(),
+			})
+		}
+	}
+	return conditions
+}
+
+// Values returns the principals, actions, resources and condition values of
+// every statement, in document order. Sid, Effect, Version and Id are left
+// out, as they label the policy rather than grant anything.
+func (p *Policy) Values() []*PolicyString {
+	var values []*PolicyString
+	for _, statement := range p.Statement {
+		for _, principals := range [][]*PolicyPrincipal{statement.Principal, statement.NotPrincipal} {
+			for _, principal := range principals {
+				values = append(values, principal.Values...)
+			}
+		}
+		values = append(values, statement.Action...)
+		values = append(values, statement.NotAction...)
+		values = append(values, statement.Resource...)
+		values = append(values, statement.NotResource...)
+		for _, condition := range statement.Condition {
+			values = append(values, condition.Values...)
+		}
+	}
+
+	sort.SliceStable(values, func(i, j int) bool {
+		return values[i].Start < values[j].Start
+	})
+	return values
+}
+
+// JSONStrings returns the string values of a JSON document, such as a policy
+// or a list of container definitions, in document order. Object keys are left
+// out. Template placeholders are read as they are by ParsePolicy.
+func JSONStrings(text string) ([]*PolicyString, error) {
+	root, err := parseJSON(text)
+	if err != nil {
+		return nil, err
+	}
+
+	var strs []*PolicyString
+	var walk func(node *jsonNode)
+	walk = func(node *jsonNode) {
+		switch node.kind {
+		case jsonString:
+			strs = append(strs, node.policyString())
+		case jsonObject, jsonArray:
+			for _, value := range node.values {
+				walk(value)
+			}
+		}
+	}
+	walk(root)
+
+	return strs, nil
+}
+
+type jsonKind int
+
+const (
+	jsonString jsonKind = iota
+	jsonScalar
+	jsonObject
+	jsonArray
+
+	// jsonPlaceholder is a template placeholder standing for a value that is
+	// not known
+	jsonPlaceholder
+)
+
+// jsonNode is a JSON value and the byte range of its text
+type jsonNode struct {
+	kind  jsonKind
+	start int
+	end   int
+
+	// str is the decoded string, or the raw text of a number, boolean or null
+	str string
+
+	// keys and values are the members of an object, values the elements of an array
+	keys   []string
+	values []*jsonNode
+}
+
+// list returns the elements of an array, or the node itself for a single value
+func (n *jsonNode) list() []*jsonNode {
+	if n.kind == jsonArray {
+		return n.values
+	}
+	return []*jsonNode{n}
+}
+
+func (n *jsonNode) policyString() *PolicyString {
+	if n.kind != jsonString && (n.kind != jsonScalar || n.str == "null") {
+		return nil
+	}
+	return &PolicyString{Value: n.str, Start: n.start, End: n.end}
+}
+
+func (n *jsonNode) policyStrings() []*PolicyString {
+	var strs []*PolicyString
+	for _, node := range n.list() {
+		if s := node.policyString(); s != nil {
+			strs = append(strs, s)
+		}
+	}
+	return strs
+}
+
+// jsonParser parses JSON text while keeping the byte range of every value,
+// which encoding/json does not expose
+type jsonParser struct {
+	text string
+	pos  int
+}
+
+func parseJSON(text string) (*jsonNode, error) {
+	p := &jsonParser{text: text}
+	node, err := p.value()
+	if err != nil {
+		return nil, err
+	}
+
+	p.skipSpace()
+	if p.pos < len(p.text) {
+		return nil, p.errorf("unexpected text after the document")
+	}
+	return node, nil
+}
+
+func (p *jsonParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("invalid policy JSON at byte %d: %s", p.pos, fmt.Sprintf(format, args...))
+}
+
+func (p *jsonParser) skipSpace() {
+	for p.pos < len(p.text) && strings.IndexByte(" \t\r\n", p.text[p.pos]) >= 0 {
+		p.pos++
+	}
+}
+
+func (p *jsonParser) value() (*jsonNode, error) {
+	p.skipSpace()
+	if p.pos >= len(p.text) {
+		return nil, p.errorf("unexpected end of the document")
+	}
+
+	switch p.text[p.pos] {
+	case '{':
+		return p.object()
+	case '[':
+		return p.array()
+	case '"':
+		return p.string()
+	case '$':
+		if p.placeholder() {
+			return p.placeholders(), nil
+		}
+		return p.scalar()
+	default:
+		return p.scalar()
+	}
+}
+
+func (p *jsonParser) object() (*jsonNode, error) {
+	node := &jsonNode{kind: jsonObject, start: p.pos}
+	p.pos++
+
+	p.skipSpace()
+	if p.pos < len(p.text) && p.text[p.pos] == '}' {
+		p.pos++
+		node.end = p.pos
+		return node, nil
+	}
+
+	for {
+		p.skipSpace()
+		if p.placeholder() {
+			// A placeholder for a set of members, which can't be looked into
+			p.placeholders()
+		} else if err := p.member(node); err != nil {
+			return nil, err
+		}
+
+		done, err := p.next('}')
+		if err != nil {
+			return nil, err
+		}
+		if done {
+			node.end = p.pos
+			return node, nil
+		}
+	}
+}
+
+// member parses a key and value of an object
+func (p *jsonParser) member(node *jsonNode) error {
+	if p.pos >= len(p.text) || p.text[p.pos] != '"' {
+		return p.errorf("expected an object key")
+	}
+	key, err := p.string()
+	if err != nil {
+		return err
+	}
+
+	p.skipSpace()
+	if p.pos >= len(p.text) || p.text[p.pos] != ':' {
+		return p.errorf("expected ':' after an object key")
+	}
+	p.pos++
+
+	value, err := p.value()
+	if err != nil {
+		return err
+	}
+	node.keys = append(node.keys, key.str)
+	node.values = append(node.values, value)
+	return nil
+}
+
+func (p *jsonParser) array() (*jsonNode, error) {
+	node := &jsonNode{kind: jsonArray, start: p.pos}
+	p.pos++
+
+	p.skipSpace()
+	if p.pos < len(p.text) && p.text[p.pos] == ']' {
+		p.pos++
+		node.end = p.pos
+		return node, nil
+	}
+
+	for {
+		value, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		node.values = append(node.values, value)
+
+		done, err := p.next(']')
+		if err != nil {
+			return nil, err
+		}
+		if done {
+			node.end = p.pos
+			return node, nil
+		}
+	}
+}
+
+// next consumes the separator after a member or element, reporting whether
+// it closed the object or array
+func (p *jsonParser) next(closing byte) (bool, error) {
+	p.skipSpace()
+	if p.pos >= len(p.text) {
+		return false, p.errorf("unexpected end of the document")
+	}
+
+	switch p.text[p.pos] {
+	case ',':
+		p.pos++
+		return false, nil
+	case closing:
+		p.pos++
+		return true, nil
+	default:
+		return false, p.errorf("expected ',' or '%c'", closing)
+	}
+}
+
+func (p *jsonParser) string() (*jsonNode, error) {
+	start := p.pos
+	for i := start + 1; i < len(p.text); i++ {
+		switch p.text[i] {
+		case '\\':
+			i++
+		case '$':
+			// Quotes within a placeholder don't end the string
+			if end := placeholderEnd(p.text, i); end > 0 {
+				i = end - 1
+			}
+		case '"':
+			p.pos = i + 1
+			str, err := decodeString(p.text[start+1 : i])
+			if err != nil {
+				p.pos = start
+				return nil, p.errorf("invalid string")
+			}
+			return &jsonNode{kind: jsonString, start: start, end: p.pos, str: str}, nil
+		}
+	}
+	return nil, p.errorf("unterminated string")
+}
+
+// decodeString decodes the contents of a JSON string, keeping the text of
+// any template placeholders as it is
+func decodeString(raw string) (string, error) {
+	var decoded strings.Builder
+	for raw != "" {
+		text := raw
+		placeholder := ""
+		for i := 0; i < len(raw); i++ {
+			if end := placeholderEnd(raw, i); end > 0 {
+				text, placeholder, raw = raw[:i], raw[i:end], raw[end:]
+				break
+			}
+		}
+		if placeholder == "" {
+			raw = ""
+		}
+
+		var str string
+		if err := json.Unmarshal([]byte(`"`+text+`"`), &str); err != nil {
+			return "", err
+		}
+		decoded.WriteString(str)
+		decoded.WriteString(placeholder)
+	}
+	return decoded.String(), nil
+}
+
+func (p *jsonParser) scalar() (*jsonNode, error) {
+	start := p.pos
+	for p.pos < len(p.text) && strings.IndexByte(",]} \t\r\n", p.text[p.pos]) < 0 {
+		p.pos++
+	}
+
+	raw := p.text[start:p.pos]
+	if raw == "" || !json.Valid([]byte(raw)) {
+		p.pos = start
+		return nil, p.errorf("unexpected %q", raw)
+	}
+	return &jsonNode{kind: jsonScalar, start: start, end: p.pos, str: raw}, nil
+}
+
+// placeholder reports whether a template placeholder starts at the current position
+func (p *jsonParser) placeholder() bool {
+	return placeholderEnd(p.text, p.pos) > 0
+}
+
+// placeholders consumes one or more adjacent template placeholders
+func (p *jsonParser) placeholders() *jsonNode {
+	node := &jsonNode{kind: jsonPlaceholder, start: p.pos}
+	for {
+		p.pos = placeholderEnd(p.text, p.pos)
+		node.end = p.pos
+		if !p.placeholder() {
+			return node
+		}
+	}
+}
+
+// placeholderEnd returns the end of the ${...} placeholder starting at pos,
+// or 0 if there is none. Braces and strings within it are matched, as in
+// ${lookup(var.names, "}")}.
+func placeholderEnd(text string, pos int) int {
+	if !strings.HasPrefix(text[pos:], "${") {
+		return 0
+	}
+
+	depth := 0
+	for i := pos + 1; i < len(text); i++ {
+		switch text[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i + 1
+			}
+		case '"':
+			for i++; i < len(text) && text[i] != '"'; i++ {
+				if text[i] == '\\' {
+					i++
+				}
+			}
+		}
+	}
+	return 0
+}
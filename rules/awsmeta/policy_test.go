@@ -0,0 +1,166 @@
+package awsmeta
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParsePolicy(t *testing.T) {
+	text := `{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Sid": "AllowEuWest1",
+      "Effect": "Allow",
+      "Principal": {"Service": ["lambda.amazonaws.com", "s3.amazonaws.com"]},
+      "Action": "sqs:SendMessage",
+      "Resource": ["arn:aws:sqs:eu-west-1:123456789012:queue"],
+      "Condition": {
+        "StringEquals": {"aws:RequestedRegion": "eu-west-1"},
+        "Bool": {"aws:SecureTransport": true}
+      }
+    },
+    {
+      "Effect": "Deny",
+      "NotPrincipal": "*",
+      "NotAction": ["s3:*"],
+      "NotResource": "arn:aws:s3:::bucket\/*"
+    }
+  ]
+}`
+
+	policy, err := ParsePolicy(text)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if policy.Version == nil || policy.Version.Value != "2012-10-17" {
+		t.Errorf("Expected version 2012-10-17, got %+v", policy.Version)
+	}
+	if len(policy.Statement) != 2 {
+		t.Fatalf("Expected 2 statements, got %d", len(policy.Statement))
+	}
+
+	first := policy.Statement[0]
+	if first.Sid == nil || first.Sid.Value != "AllowEuWest1" {
+		t.Errorf("Expected Sid AllowEuWest1, got %+v", first.Sid)
+	}
+	if len(first.Principal) != 1 || first.Principal[0].Type != "Service" || len(first.Principal[0].Values) != 2 {
+		t.Errorf("Expected 2 Service principals, got %+v", first.Principal)
+	}
+	if len(first.Condition) != 2 || first.Condition[0].Key != "aws:RequestedRegion" || first.Condition[1].Values[0].Value != "true" {
+		t.Errorf("Unexpected conditions %+v", first.Condition)
+	}
+
+	second := policy.Statement[1]
+	if len(second.NotPrincipal) != 1 || second.NotPrincipal[0].Type != "*" {
+		t.Errorf("Expected a wildcard NotPrincipal, got %+v", second.NotPrincipal)
+	}
+	if len(second.NotResource) != 1 || second.NotResource[0].Value != "arn:aws:s3:::bucket/*" {
+		t.Errorf("Expected the escaped NotResource to be decoded, got %+v", second.NotResource)
+	}
+
+	var values []string
+	for _, value := range policy.Values() {
+		values = append(values, value.Value)
+
+		// Every value maps back to its token in the document text
+		token := text[value.Start:value.End]
+		if !strings.Contains(token, strings.Split(value.Value, "/")[0]) {
+			t.Errorf("Value %q does not match its token %q", value.Value, token)
+		}
+	}
+
+	expected := []string{
+		"lambda.amazonaws.com", "s3.amazonaws.com", "sqs:SendMessage",
+		"arn:aws:sqs:eu-west-1:123456789012:queue", "eu-west-1", "true",
+		"*", "s3:*", "arn:aws:s3:::bucket/*",
+	}
+	if strings.Join(values, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected values %v, got %v", expected, values)
+	}
+}
+
+func TestParsePolicySingleStatement(t *testing.T) {
+	policy, err := ParsePolicy(`{"Statement": {"Effect": "Allow", "Resource": "*"}}`)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if len(policy.Statement) != 1 || len(policy.Statement[0].Resource) != 1 {
+		t.Errorf("Expected a single statement with one resource, got %+v", policy.Statement)
+	}
+}
+
+func TestParsePolicyInvalid(t *testing.T) {
+	testCases := []string{
+		``,
+		`not json`,
+		`["Statement"]`,
+		`{"Statement": [}`,
+		`{"Version": "2012-10-17"} trailing`,
+		`{"Version": "2012-10-17`,
+		`{"Statement": ${statements`,
+		`{"Statement": ${a} []}`,
+	}
+
+	for _, text := range testCases {
+		if _, err := ParsePolicy(text); err == nil {
+			t.Errorf("Expected an error for %q", text)
+		}
+	}
+}
+
+func TestParsePolicyTemplate(t *testing.T) {
+	text := `{
+  "Statement": [
+    ${statements},
+    {
+      "Effect": "Allow",
+      "Action": ${jsonencode(var.actions)},
+      "Resource": ["arn:aws:s3:::${lookup(var.buckets, "logs")}/*", "arn:aws:sqs:eu-west-1:123456789012:queue"],
+      ${extra}
+    }
+  ]
+}`
+
+	policy, err := ParsePolicy(text)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if len(policy.Statement) != 1 {
+		t.Fatalf("Expected 1 statement, got %d", len(policy.Statement))
+	}
+
+	statement := policy.Statement[0]
+	if len(statement.Action) != 0 {
+		t.Errorf("Expected the placeholder action to be left out, got %+v", statement.Action)
+	}
+	if len(statement.Resource) != 2 || statement.Resource[0].Value != `arn:aws:s3:::${lookup(var.buckets, "logs")}/*` {
+		t.Errorf("Expected the placeholder to be kept within the resource, got %+v", statement.Resource)
+	}
+	if resource := statement.Resource[1]; text[resource.Start:resource.End] != `"arn:aws:sqs:eu-west-1:123456789012:queue"` {
+		t.Errorf("Unexpected range of %q", resource.Value)
+	}
+}
+
+func TestJSONStrings(t *testing.T) {
+	text := `[{"name": "app", "essential": true, "environment": [{"name": "REGION", "value": "eu-west-1"}], "image": "${image}"}]`
+
+	strs, err := JSONStrings(text)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	var values []string
+	for _, str := range strs {
+		values = append(values, str.Value)
+		if text[str.Start] != '"' || text[str.End-1] != '"' {
+			t.Errorf("Value %q does not map to its token", str.Value)
+		}
+	}
+
+	expected := []string{"app", "REGION", "eu-west-1", "${image}"}
+	if strings.Join(values, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected values %v, got %v", expected, values)
+	}
+}
@@ -0,0 +1,244 @@
+package rules
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"sync"
+
+	"github.com/hashicorp/hcl/v2"
+	hcljson "github.com/hashicorp/hcl/v2/json"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/gocty"
+)
+
+// baselineEntry is a known finding, fingerprinted by where it is in the
+// configuration rather than by its line, so it survives unrelated edits
+type baselineEntry struct {
+	Rule string `json:"rule" cty:"rule"`
+
+	// Module is the path of the module holding the finding, such as
+	// "module.vpc". It is empty in the root module, and optional in the file.
+	Module string `json:"module,omitempty"`
+
+	// Address is the address of the block holding the finding, such as
+	// "aws_instance.web", or the file name outside of any block
+	Address string `json:"address" cty:"address"`
+
+	// Attribute is the path to the finding within its block
+	Attribute string `json:"attribute" cty:"attribute"`
+
+	Value string `json:"value" cty:"value"`
+}
+
+// newBaselineEntry returns the fingerprint of a finding of the rule at rng in
+// the given module
+func newBaselineEntry(files map[string]*hcl.File, module string, rule tflint.Rule, match Match, rng hcl.Range) baselineEntry {
+	loc := locate(files, rng)
+	return baselineEntry{
+		Rule:      rule.Name(),
+		Module:    module,
+		Address:   issueAddress(loc, rng),
+		Attribute: loc.AttributePath(),
+		Value:     match.Value,
+	}
+}
+
+// baseline is a JSON file of known findings, such as
+//
+//	{
+//	  "findings": [
+//	    {"rule": "aws_meta_hardcoded", "address": "aws_instance.web", "attribute": "availability_zone", "value": "us-east-1a"},
+//	    {"rule": "aws_meta_hardcoded", "module": "module.vpc", "address": "aws_subnet.a", "attribute": "availability_zone", "value": "us-east-1b"}
+//	  ]
+//	}
+//
+// Every module is checked on its own, so the baseline is shared by the checks
+// of a run, each of which only matches and updates the entries of its module.
+type baseline struct {
+	Filename string
+	Entries  []baselineEntry
+
+	// Ranges are the ranges of the entries in the file, until it is updated
+	Ranges []hcl.Range
+
+	mu sync.Mutex
+}
+
+// loadBaseline reads a baseline file
+func loadBaseline(filename string) (*baseline, error) {
+	src, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	file, diags := hcljson.Parse(src, filename)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	attrs, diags := file.Body.JustAttributes()
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	b := &baseline{Filename: filename}
+	attr, ok := attrs["findings"]
+	if !ok {
+		return b, nil
+	}
+	exprs, diags := hcl.ExprList(attr.Expr)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	for _, expr := range exprs {
+		value, diags := expr.Value(nil)
+		if diags.HasErrors() {
+			return nil, diags
+		}
+		var entry baselineEntry
+		if value.Type().IsObjectType() && value.Type().HasAttribute("module") && !value.IsNull() {
+			attrs := value.AsValueMap()
+			if err := gocty.FromCtyValue(attrs["module"], &entry.Module); err != nil {
+				return nil, fmt.Errorf("%s: invalid finding: %w", expr.Range(), err)
+			}
+			delete(attrs, "module")
+			value = cty.ObjectVal(attrs)
+		}
+		if err := gocty.FromCtyValue(value, &entry); err != nil {
+			return nil, fmt.Errorf("%s: invalid finding: %w", expr.Range(), err)
+		}
+		b.Entries = append(b.Entries, entry)
+		b.Ranges = append(b.Ranges, expr.Range())
+	}
+	return b, nil
+}
+
+// writeBaseline writes the entries to a baseline file, sorted so the file
+// only changes when the findings do
+func writeBaseline(filename string, entries []baselineEntry) error {
+	entries = slices.Clone(entries)
+	slices.SortFunc(entries, func(a, b baselineEntry) int {
+		return cmp.Or(
+			cmp.Compare(a.Rule, b.Rule),
+			cmp.Compare(a.Module, b.Module),
+			cmp.Compare(a.Address, b.Address),
+			cmp.Compare(a.Attribute, b.Attribute),
+			cmp.Compare(a.Value, b.Value),
+		)
+	})
+
+	src, err := json.MarshalIndent(struct {
+		Findings []baselineEntry `json:"findings"`
+	}{Findings: entries}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, append(src, '\n'), 0o644)
+}
+
+// baselineCheck matches the findings of the check of one module against the
+// baseline. In update mode every finding is recorded instead, to write a new
+// baseline.
+type baselineCheck struct {
+	baseline *baseline
+	module   string
+	update   bool
+
+	// enabled are the names of the rules that run in the check. Entries of
+	// other rules are neither stale nor dropped by an update.
+	enabled map[string]bool
+
+	// unmatched are the indexes of the entries of the module no finding has
+	// matched yet
+	unmatched map[baselineEntry][]int
+
+	// recorded are the findings of the check in update mode
+	recorded []baselineEntry
+}
+
+func newBaselineCheck(b *baseline, module string, update bool, enabled map[string]bool) *baselineCheck {
+	c := &baselineCheck{
+		baseline:  b,
+		module:    module,
+		update:    update,
+		enabled:   enabled,
+		unmatched: make(map[baselineEntry][]int),
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for i, entry := range b.Entries {
+		if entry.Module == module {
+			c.unmatched[entry] = append(c.unmatched[entry], i)
+		}
+	}
+	return c
+}
+
+// match reports whether the finding is known, using up the entry it matches.
+// An entry matches a single finding, so a value repeated in the same
+// attribute needs an entry for every time it is found.
+func (c *baselineCheck) match(entry baselineEntry) bool {
+	if c.update {
+		c.recorded = append(c.recorded, entry)
+		return true
+	}
+	indexes := c.unmatched[entry]
+	if len(indexes) == 0 {
+		return false
+	}
+	c.unmatched[entry] = indexes[1:]
+	return true
+}
+
+// stale returns the indexes of the entries of the enabled rules that matched
+// no finding, in file order
+func (c *baselineCheck) stale() []int {
+	var stale []int
+	for entry, indexes := range c.unmatched {
+		if c.enabled[entry.Rule] {
+			stale = append(stale, indexes...)
+		}
+	}
+	slices.Sort(stale)
+	return stale
+}
+
+// write replaces the entries of the module with the recorded findings and
+// writes the baseline file. The entries of other modules, which are written
+// by their own checks, and of the rules that didn't run are kept.
+func (c *baselineCheck) write() error {
+	b := c.baseline
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	entries := slices.Clone(c.recorded)
+	for _, entry := range b.Entries {
+		if entry.Module != c.module || !c.enabled[entry.Rule] {
+			entries = append(entries, entry)
+		}
+	}
+	b.Entries, b.Ranges = entries, nil
+	return writeBaseline(b.Filename, entries)
+}
+
+// baselined reports whether the baseline of the check holds the finding, in
+// which case it isn't reported. Findings the plugin configuration excludes
+// are left for EmitIssue to drop, so they never make it into the baseline.
+func baselined(runner tflint.Runner, rule tflint.Rule, match Match, rng hcl.Range) (bool, error) {
+	r, ok := runner.(*checkRunner)
+	if !ok || r.baseline == nil {
+		return false, nil
+	}
+	excluded, err := r.excluded(rng)
+	if err != nil || excluded {
+		return false, err
+	}
+	files, err := r.moduleFiles()
+	if err != nil {
+		return false, err
+	}
+	return r.baseline.match(newBaselineEntry(files, r.baseline.module, rule, match, rng)), nil
+}
@@ -0,0 +1,313 @@
+package rules
+
+import (
+	"os"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+	"github.com/terraform-linters/tflint-plugin-sdk/terraform/addrs"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+const baselineContent = `
+resource "aws_instance" "test" {
+  availability_zone = "us-east-1a"
+  tags = {
+    Region = "us-east-1"
+    Backup = "us-east-1"
+  }
+}`
+
+// checkBaseline runs the hardcoded and baseline rules over baselineContent
+// with the given plugin configuration
+func checkBaseline(t *testing.T, config string) (*helper.Runner, tflint.Rule, tflint.Rule) {
+	t.Helper()
+
+	testRunner := &moduleRunner{Runner: helper.TestRunner(t, map[string]string{"main.tf": baselineContent})}
+	metaRule, baselineRule := checkBaselineModules(t, config, testRunner)
+	return testRunner.Runner, metaRule, baselineRule
+}
+
+// moduleRunner is a test runner of the module at the given path, as the
+// helper runner always checks the root module
+type moduleRunner struct {
+	*helper.Runner
+	path addrs.Module
+}
+
+func (r *moduleRunner) GetModulePath() (addrs.Module, error) {
+	return r.path, nil
+}
+
+// checkBaselineModules runs the hardcoded and baseline rules over every module
+// in turn, as TFLint does, with the given plugin configuration
+func checkBaselineModules(t *testing.T, config string, modules ...*moduleRunner) (tflint.Rule, tflint.Rule) {
+	t.Helper()
+
+	metaRule := NewAwsMetaHardcodedRule()
+	baselineRule := NewAwsMetaBaselineRule()
+	ruleset := &RuleSet{BuiltinRuleSet: tflint.BuiltinRuleSet{
+		Rules: []tflint.Rule{metaRule, baselineRule},
+	}}
+	if err := ruleset.ApplyGlobalConfig(&tflint.Config{}); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	applyPluginConfig(t, ruleset, config)
+
+	for _, module := range modules {
+		runner, err := ruleset.NewRunner(module)
+		if err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+		for _, rule := range ruleset.EnabledRules {
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+		}
+	}
+	return metaRule, baselineRule
+}
+
+func Test_RuleSetBaseline(t *testing.T) {
+	t.Chdir(t.TempDir())
+	known := `{
+  "findings": [
+    {"rule": "aws_meta_hardcoded", "address": "aws_instance.test", "attribute": "availability_zone", "value": "us-east-1a"},
+    {"rule": "aws_meta_hardcoded", "address": "aws_instance.test", "attribute": "tags.Region", "value": "us-east-1"},
+    {"rule": "aws_meta_hardcoded", "address": "aws_instance.test", "attribute": "tags.Region", "value": "us-east-1"},
+    {"rule": "aws_hardcoded_ids", "address": "aws_instance.test", "attribute": "ami", "value": "ami-12345678"}
+  ]
+}`
+	if err := os.WriteFile("baseline.json", []byte(known), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	testRunner, metaRule, baselineRule := checkBaseline(t, `baseline_file = "baseline.json"`)
+
+	helper.AssertIssues(t, helper.Issues{
+		{
+			Rule:    metaRule,
+			Message: "[AWSMETA001 kind=region value=us-east-1 address=aws_instance.test replacement=data.aws_region.current.name] Hardcoded AWS region 'us-east-1' found. Consider using data.aws_region.current.name",
+			Range: hcl.Range{
+				Filename: "main.tf",
+				Start:    hcl.Pos{Line: 6, Column: 15},
+				End:      hcl.Pos{Line: 6, Column: 24},
+			},
+		},
+		{
+			Rule:    baselineRule,
+			Message: "[AWSMETA103] Stale baseline entry at baseline.json:5. aws_meta_hardcoded no longer reports 'us-east-1' in aws_instance.test.tags.Region",
+			Range: hcl.Range{
+				Filename: "main.tf",
+				Start:    hcl.Pos{Line: 2, Column: 1},
+				End:      hcl.Pos{Line: 2, Column: 31},
+			},
+		},
+	}, testRunner.Issues)
+}
+
+func Test_RuleSetUpdateBaseline(t *testing.T) {
+	t.Chdir(t.TempDir())
+	known := `{
+  "findings": [
+    {"rule": "aws_hardcoded_ids", "address": "aws_instance.test", "attribute": "ami", "value": "ami-12345678"},
+    {"rule": "aws_meta_hardcoded", "address": "aws_instance.old", "attribute": "availability_zone", "value": "us-east-1a"}
+  ]
+}`
+	if err := os.WriteFile("baseline.json", []byte(known), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	testRunner, _, _ := checkBaseline(t, `
+baseline_file   = "baseline.json"
+update_baseline = true`)
+
+	if len(testRunner.Issues) != 0 {
+		t.Errorf("Expected no issues, got %d", len(testRunner.Issues))
+	}
+
+	expected := `{
+  "findings": [
+    {
+      "rule": "aws_hardcoded_ids",
+      "address": "aws_instance.test",
+      "attribute": "ami",
+      "value": "ami-12345678"
+    },
+    {
+      "rule": "aws_meta_hardcoded",
+      "address": "aws_instance.test",
+      "attribute": "availability_zone",
+      "value": "us-east-1a"
+    },
+    {
+      "rule": "aws_meta_hardcoded",
+      "address": "aws_instance.test",
+      "attribute": "tags.Backup",
+      "value": "us-east-1"
+    },
+    {
+      "rule": "aws_meta_hardcoded",
+      "address": "aws_instance.test",
+      "attribute": "tags.Region",
+      "value": "us-east-1"
+    }
+  ]
+}
+`
+	written, err := os.ReadFile("baseline.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(written) != expected {
+		t.Errorf("Expected baseline:\n%s\ngot:\n%s", expected, written)
+	}
+}
+
+func Test_RuleSetModuleBaseline(t *testing.T) {
+	const vpcContent = `
+resource "aws_subnet" "a" {
+  availability_zone = "us-east-1b"
+}`
+	known := `{
+  "findings": [
+    {"rule": "aws_meta_hardcoded", "address": "aws_instance.test", "attribute": "availability_zone", "value": "us-east-1a"},
+    {"rule": "aws_meta_hardcoded", "address": "aws_instance.test", "attribute": "tags.Backup", "value": "us-east-1"},
+    {"rule": "aws_meta_hardcoded", "address": "aws_instance.test", "attribute": "tags.Region", "value": "us-east-1"},
+    {"rule": "aws_meta_hardcoded", "module": "module.vpc", "address": "aws_subnet.a", "attribute": "availability_zone", "value": "us-east-1b"},
+    {"rule": "aws_meta_hardcoded", "module": "module.vpc", "address": "aws_subnet.b", "attribute": "availability_zone", "value": "us-east-1c"}
+  ]
+}`
+
+	t.Run("report", func(t *testing.T) {
+		t.Chdir(t.TempDir())
+		if err := os.WriteFile("baseline.json", []byte(known), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		root := &moduleRunner{Runner: helper.TestRunner(t, map[string]string{"main.tf": baselineContent})}
+		vpc := &moduleRunner{Runner: helper.TestRunner(t, map[string]string{"vpc.tf": vpcContent}), path: addrs.Module{"vpc"}}
+		_, baselineRule := checkBaselineModules(t, `baseline_file = "baseline.json"`, root, vpc)
+
+		helper.AssertIssues(t, helper.Issues{}, root.Issues)
+		helper.AssertIssues(t, helper.Issues{
+			{
+				Rule:    baselineRule,
+				Message: "[AWSMETA103] Stale baseline entry at baseline.json:7. aws_meta_hardcoded no longer reports 'us-east-1c' in aws_subnet.b.availability_zone",
+				Range: hcl.Range{
+					Filename: "vpc.tf",
+					Start:    hcl.Pos{Line: 1, Column: 1},
+					End:      hcl.Pos{Line: 1, Column: 1},
+				},
+			},
+		}, vpc.Issues)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		t.Chdir(t.TempDir())
+		if err := os.WriteFile("baseline.json", []byte(known), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		root := &moduleRunner{Runner: helper.TestRunner(t, map[string]string{"main.tf": baselineContent})}
+		vpc := &moduleRunner{Runner: helper.TestRunner(t, map[string]string{"vpc.tf": vpcContent}), path: addrs.Module{"vpc"}}
+		checkBaselineModules(t, `
+baseline_file   = "baseline.json"
+update_baseline = true`, root, vpc)
+
+		expected := `{
+  "findings": [
+    {
+      "rule": "aws_meta_hardcoded",
+      "address": "aws_instance.test",
+      "attribute": "availability_zone",
+      "value": "us-east-1a"
+    },
+    {
+      "rule": "aws_meta_hardcoded",
+      "address": "aws_instance.test",
+      "attribute": "tags.Backup",
+      "value": "us-east-1"
+    },
+    {
+      "rule": "aws_meta_hardcoded",
+      "address": "aws_instance.test",
+      "attribute": "tags.Region",
+      "value": "us-east-1"
+    },
+    {
+      "rule": "aws_meta_hardcoded",
+      "module": "module.vpc",
+      "address": "aws_subnet.a",
+      "attribute": "availability_zone",
+      "value": "us-east-1b"
+    }
+  ]
+}
+`
+		written, err := os.ReadFile("baseline.json")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(written) != expected {
+			t.Errorf("Expected baseline:\n%s\ngot:\n%s", expected, written)
+		}
+	})
+}
+
+func Test_RuleSetInvalidBaseline(t *testing.T) {
+	t.Chdir(t.TempDir())
+	if err := os.WriteFile("baseline.json", []byte(`{"findings": [{"rule": "aws_meta_hardcoded"}]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		Name   string
+		Config string
+		Only   []string
+	}{
+		{
+			Name:   "missing baseline file",
+			Config: `baseline_file = "missing.json"`,
+		},
+		{
+			Name:   "malformed finding",
+			Config: `baseline_file = "baseline.json"`,
+		},
+		{
+			Name:   "update without baseline file",
+			Config: `update_baseline = true`,
+		},
+		{
+			Name: "update without the baseline rule",
+			Config: `
+baseline_file   = "new.json"
+update_baseline = true`,
+			Only: []string{"aws_meta_hardcoded"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			ruleset := &RuleSet{BuiltinRuleSet: tflint.BuiltinRuleSet{
+				Rules: []tflint.Rule{NewAwsMetaHardcodedRule(), NewAwsMetaBaselineRule()},
+			}}
+			if err := ruleset.ApplyGlobalConfig(&tflint.Config{Only: test.Only}); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			file, _ := hclsyntax.ParseConfig([]byte(test.Config), "plugin.hcl", hcl.InitialPos)
+			content, diags := hclext.Content(file.Body, ruleset.ConfigSchema())
+			if diags.HasErrors() {
+				t.Fatal(diags)
+			}
+
+			if err := ruleset.ApplyConfig(content); err == nil {
+				t.Fatal("Expected an error, got none")
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package rules
+
+import (
+	"sync"
+
+	"github.com/myerscode/tflint-ruleset-aws-meta/rules/awsmeta"
+)
+
+// catalogMatcher builds the matcher of a catalog on first use, so a catalog
+// that fails to load is reported by the rules needing it
+type catalogMatcher struct {
+	catalog awsmeta.Catalog
+	once    sync.Once
+	matcher *awsmeta.Matcher
+	err     error
+}
+
+func newCatalogMatcher(catalog awsmeta.Catalog) *catalogMatcher {
+	return &catalogMatcher{catalog: catalog}
+}
+
+func (c *catalogMatcher) get() (*awsmeta.Matcher, error) {
+	c.once.Do(func() {
+		c.matcher, c.err = awsmeta.NewMatcher(c.catalog)
+	})
+	return c.matcher, c.err
+}
+
+// defaultMatcher is shared by the rules that use the default catalog
+var defaultMatcher = newCatalogMatcher(awsmeta.DefaultCatalog())
+
+// withCatalog is embedded by rules that match values against an AWS catalog.
+// Rules use the default catalog unless they are given another one.
+type withCatalog struct {
+	catalog *catalogMatcher
+}
+
+// catalogRule is a rule that matches values against an AWS catalog
+type catalogRule interface {
+	useCatalog(catalog awsmeta.Catalog)
+}
+
+// useCatalog makes the rule match values against the given catalog
+func (w *withCatalog) useCatalog(catalog awsmeta.Catalog) {
+	w.catalog = newCatalogMatcher(catalog)
+}
+
+// matcher returns the matcher of the rule's catalog
+func (w *withCatalog) matcher() (*awsmeta.Matcher, error) {
+	if w.catalog == nil {
+		return defaultMatcher.get()
+	}
+	return w.catalog.get()
+}
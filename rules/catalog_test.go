@@ -0,0 +1,166 @@
+package rules
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/myerscode/tflint-ruleset-aws-meta/rules/awsmeta"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+type fakeCatalog struct {
+	regions    []string
+	partitions []awsmeta.Partition
+	azSuffix   string
+	err        error
+}
+
+func (c fakeCatalog) Regions() ([]string, error) {
+	return c.regions, c.err
+}
+
+func (c fakeCatalog) AvailabilityZoneSuffix() string {
+	if c.azSuffix == "" {
+		return "[a-z]"
+	}
+	return c.azSuffix
+}
+
+func (c fakeCatalog) Partitions() ([]awsmeta.Partition, error) {
+	return c.partitions, c.err
+}
+
+var marsCatalog = fakeCatalog{
+	regions:    []string{"mars-north-1"},
+	partitions: []awsmeta.Partition{{ID: "aws-mars", DNSSuffix: "amazonaws.mars"}},
+}
+
+func Test_WithCatalog(t *testing.T) {
+	content := `
+resource "aws_instance" "test" {
+  availability_zone = "mars-north-1a"
+  tags = {
+    Earth = "eu-west-1"
+    Queue = "arn:aws-mars:sqs:mars-north-1:123456789012:jobs"
+  }
+}`
+
+	rule := NewAwsMetaHardcodedRule()
+	rule.useCatalog(marsCatalog)
+	runner := helper.TestRunner(t, map[string]string{"main.tf": content})
+
+	if err := rule.Check(runner); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	helper.AssertIssues(t, helper.Issues{
+		{
+			Rule:    rule,
+			Message: "[AWSMETA003 kind=availability_zone value=mars-north-1a address=aws_instance.test replacement=data.aws_availability_zones.available.names] Hardcoded AWS availability zone 'mars-north-1a' found. Consider using data.aws_availability_zones to dynamically fetch AZs for the current region",
+			Range: hcl.Range{
+				Filename: "main.tf",
+				Start:    hcl.Pos{Line: 3, Column: 24},
+				End:      hcl.Pos{Line: 3, Column: 37},
+			},
+		},
+		{
+			Rule:    rule,
+			Message: "[AWSMETA001 kind=region value=mars-north-1 address=aws_instance.test replacement=data.aws_region.current.name] Hardcoded AWS region 'mars-north-1' found in ARN. Consider using data.aws_region.current.name",
+			Range: hcl.Range{
+				Filename: "main.tf",
+				Start:    hcl.Pos{Line: 6, Column: 31},
+				End:      hcl.Pos{Line: 6, Column: 43},
+			},
+		},
+		{
+			Rule:    rule,
+			Message: "[AWSMETA002 kind=partition value=aws-mars address=aws_instance.test replacement=data.aws_partition.current.partition] Hardcoded AWS partition 'aws-mars' found in ARN. Consider using data.aws_partition.current.partition",
+			Range: hcl.Range{
+				Filename: "main.tf",
+				Start:    hcl.Pos{Line: 6, Column: 18},
+				End:      hcl.Pos{Line: 6, Column: 26},
+			},
+		},
+	}, runner.Issues)
+}
+
+func Test_WithCatalogAllowedLocalZones(t *testing.T) {
+	catalog := fakeCatalog{
+		regions:    []string{"us-west-2", "us-east-1"},
+		partitions: []awsmeta.Partition{{ID: "aws", DNSSuffix: "amazonaws.com"}},
+		azSuffix:   `[a-z]|-[a-z]{3}-\d+[a-z]`,
+	}
+	content := `
+resource "aws_instance" "lax" {
+  availability_zone = "us-west-2-lax-1a"
+}
+
+resource "aws_instance" "bos" {
+  availability_zone = "us-east-1-bos-1a"
+}`
+	config := `
+rule "aws_meta_hardcoded" {
+  enabled         = true
+  allowed_regions = ["us-west-2"]
+}`
+
+	rule := NewAwsMetaHardcodedRule()
+	rule.useCatalog(catalog)
+	runner := helper.TestRunner(t, map[string]string{"main.tf": content, ".tflint.hcl": config})
+
+	if err := rule.Check(runner); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	helper.AssertIssues(t, helper.Issues{
+		{
+			Rule:    rule,
+			Message: "[AWSMETA003 kind=availability_zone value=us-east-1-bos-1a address=aws_instance.bos replacement=data.aws_availability_zones.available.names] Hardcoded AWS availability zone 'us-east-1-bos-1a' found. Consider using data.aws_availability_zones to dynamically fetch AZs for the current region",
+			Range: hcl.Range{
+				Filename: "main.tf",
+				Start:    hcl.Pos{Line: 7, Column: 24},
+				End:      hcl.Pos{Line: 7, Column: 40},
+			},
+		},
+	}, runner.Issues)
+}
+
+func Test_WithCatalogError(t *testing.T) {
+	catalog := fakeCatalog{err: errors.New("failed to load AWS regions: broken")}
+	content := `
+provider "aws" {
+  region = "us-east-1"
+}
+
+resource "aws_iam_policy" "test" {
+  policy = "{\"Resource\": \"arn:aws:s3:::bucket\"}"
+}`
+
+	rules := []interface {
+		tflint.Rule
+		catalogRule
+	}{
+		NewAwsMetaHardcodedRule(),
+		NewAwsHardcodedIDsRule(),
+		NewAwsServicePrincipalHardcodedRule(),
+		NewAwsProviderHardcodedRegionRule(),
+		NewAwsIamPolicyHardcodedRegionRule(),
+		NewAwsIamPolicyHardcodedPartitionRule(),
+		NewAwsIamRolePolicyHardcodedRegionRule(),
+		NewAwsIamRolePolicyHardcodedPartitionRule(),
+	}
+
+	for _, rule := range rules {
+		t.Run(rule.Name(), func(t *testing.T) {
+			rule.useCatalog(catalog)
+			runner := helper.TestRunner(t, map[string]string{"main.tf": content})
+
+			err := rule.Check(runner)
+			if err == nil || err.Error() != "failed to load AWS regions: broken" {
+				t.Fatalf("Check() error = %v, want the catalog error", err)
+			}
+		})
+	}
+}
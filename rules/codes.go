@@ -0,0 +1,76 @@
+package rules
+
+import (
+	"strconv"
+	"strings"
+)
+
+// findingCode is the stable code of the issues of a finding kind, and the
+// expression they suggest using instead of the hardcoded value
+type findingCode struct {
+	Code        string
+	Replacement string
+}
+
+// findingCodes are the codes of the finding kinds. Codes are never reused
+// or renumbered, so tooling can rely on them across releases.
+var findingCodes = map[string]findingCode{
+	kindRegion:           {Code: "AWSMETA001", Replacement: "data.aws_region.current.name"},
+	kindPartition:        {Code: "AWSMETA002", Replacement: "data.aws_partition.current.partition"},
+	kindAvailabilityZone: {Code: "AWSMETA003", Replacement: "data.aws_availability_zones.available.names"},
+	kindAccountID:        {Code: "AWSMETA004", Replacement: "data.aws_caller_identity.current.account_id"},
+	kindAMIID:            {Code: "AWSMETA005", Replacement: "data.aws_ami"},
+	kindServicePrincipal: {Code: "AWSMETA006", Replacement: "data.aws_service_principal"},
+	kindDNSSuffix:        {Code: "AWSMETA007", Replacement: "data.aws_service_principal"},
+	kindPrincipalSuffix:  {Code: "AWSMETA008", Replacement: "data.aws_service_principal"},
+}
+
+// Codes of the issues about the configuration of the ruleset itself
+const (
+	codeInvalidAnnotation = "AWSMETA101"
+	codeUnusedAnnotation  = "AWSMETA102"
+	codeStaleBaseline     = "AWSMETA103"
+)
+
+// findingMessage prefixes the message of a finding with its code and fields,
+// such as
+//
+//	[AWSMETA001 kind=region value=us-east-1 address=aws_instance.web replacement=data.aws_region.current.name] Hardcoded AWS region ...
+func findingMessage(match Match, address, message string) string {
+	code := findingCodes[match.Kind]
+	replacement := match.Replacement
+	if replacement == "" {
+		replacement = code.Replacement
+	}
+	return codedMessage(code.Code, message,
+		"kind", match.Kind,
+		"value", match.Value,
+		"address", address,
+		"replacement", replacement,
+	)
+}
+
+// codedMessage prefixes a message with its code and the given key and value
+// pairs. Empty values are left out, and values that would be ambiguous are
+// quoted as Go strings.
+func codedMessage(code, message string, fields ...string) string {
+	var sb strings.Builder
+	sb.WriteString("[")
+	sb.WriteString(code)
+	for i := 0; i+1 < len(fields); i += 2 {
+		key, value := fields[i], fields[i+1]
+		if value == "" {
+			continue
+		}
+		if strings.ContainsAny(value, " \t\r\n\"=]") {
+			value = strconv.Quote(value)
+		}
+		sb.WriteString(" ")
+		sb.WriteString(key)
+		sb.WriteString("=")
+		sb.WriteString(value)
+	}
+	sb.WriteString("] ")
+	sb.WriteString(message)
+	return sb.String()
+}
@@ -0,0 +1,45 @@
+package rules
+
+import "testing"
+
+func Test_FindingMessage(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Match    Match
+		Address  string
+		Expected string
+	}{
+		{
+			Name:     "default replacement",
+			Match:    Match{Kind: kindRegion, Value: "us-east-1"},
+			Address:  "aws_instance.web",
+			Expected: "[AWSMETA001 kind=region value=us-east-1 address=aws_instance.web replacement=data.aws_region.current.name] message",
+		},
+		{
+			Name:     "replacement of the match",
+			Match:    Match{Kind: kindServicePrincipal, Value: "lambda.amazonaws.com", Replacement: "data.aws_service_principal.lambda.name"},
+			Address:  "aws_iam_role.web",
+			Expected: "[AWSMETA006 kind=service_principal value=lambda.amazonaws.com address=aws_iam_role.web replacement=data.aws_service_principal.lambda.name] message",
+		},
+		{
+			Name:     "empty fields are left out",
+			Match:    Match{Kind: kindDNSSuffix},
+			Address:  "aws_iam_role.web",
+			Expected: "[AWSMETA007 kind=dns_suffix address=aws_iam_role.web replacement=data.aws_service_principal] message",
+		},
+		{
+			Name:     "ambiguous values are quoted",
+			Match:    Match{Kind: kindAccountID, Value: "123456789012"},
+			Address:  "policies/admin policy.json",
+			Expected: `[AWSMETA004 kind=account_id value=123456789012 address="policies/admin policy.json" replacement=data.aws_caller_identity.current.account_id] message`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			if got := findingMessage(test.Match, test.Address, "message"); got != test.Expected {
+				t.Errorf("Expected %q, got %q", test.Expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,296 @@
+package rules
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// Config is the configuration of the "aws-meta" plugin block
+type Config struct {
+	// ExcludePaths are glob patterns of files to skip (e.g. "generated/**/*.tf")
+	ExcludePaths []string `hclext:"exclude_paths,optional"`
+
+	// ExcludeResourceTypes are resource and data source types to skip
+	ExcludeResourceTypes []string `hclext:"exclude_resource_types,optional"`
+
+	// ExcludeAttributes are attribute paths to skip, optionally prefixed by a
+	// resource type (e.g. "aws_dynamodb_table.replica.region_name" or "tags.*")
+	ExcludeAttributes []string `hclext:"exclude_attributes,optional"`
+
+	// ExcludeModules are the names of module calls whose arguments to skip
+	ExcludeModules []string `hclext:"exclude_modules,optional"`
+
+	// CatalogFile is an HCL or JSON file of regions and partitions to add to
+	// the AWS catalog, such as regions launched after this release
+	CatalogFile string `hclext:"catalog_file,optional"`
+
+	// BaselineFile is a JSON file of known findings, which are not reported
+	BaselineFile string `hclext:"baseline_file,optional"`
+
+	// UpdateBaseline writes the findings of the check to BaselineFile instead
+	// of reporting them
+	UpdateBaseline bool `hclext:"update_baseline,optional"`
+}
+
+// exclusions is the compiled form of the exclusion settings
+type exclusions struct {
+	paths         []*regexp.Regexp
+	resourceTypes map[string]bool
+	attributes    [][]string
+
+	// modules are the addresses of the excluded module calls, such as "module.vpc"
+	modules map[string]bool
+}
+
+func newExclusions(config *Config) (*exclusions, error) {
+	e := &exclusions{resourceTypes: make(map[string]bool), modules: make(map[string]bool)}
+	if config == nil {
+		return e, nil
+	}
+
+	for _, pattern := range config.ExcludePaths {
+		re, err := compileGlob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid exclude_paths pattern %q: %w", pattern, err)
+		}
+		e.paths = append(e.paths, re)
+	}
+
+	for _, resourceType := range config.ExcludeResourceTypes {
+		e.resourceTypes[resourceType] = true
+	}
+
+	for _, pattern := range config.ExcludeAttributes {
+		segments := strings.Split(pattern, ".")
+		for _, segment := range segments {
+			if _, err := path.Match(segment, ""); err != nil {
+				return nil, fmt.Errorf("invalid exclude_attributes pattern %q: %w", pattern, err)
+			}
+		}
+		e.attributes = append(e.attributes, segments)
+	}
+
+	for _, name := range config.ExcludeModules {
+		e.modules["module."+name] = true
+	}
+
+	return e, nil
+}
+
+// needsLocation reports whether exclusions depend on where a range sits in
+// the configuration, rather than only on its file
+func (e *exclusions) needsLocation() bool {
+	return len(e.resourceTypes) > 0 || len(e.attributes) > 0 || len(e.modules) > 0
+}
+
+// excludesPath reports whether the file is excluded
+func (e *exclusions) excludesPath(filename string) bool {
+	filename = filepath.ToSlash(filename)
+	for _, re := range e.paths {
+		if re.MatchString(filename) {
+			return true
+		}
+	}
+	return false
+}
+
+// excludesLocation reports whether the module call, resource type or
+// attribute path is excluded
+func (e *exclusions) excludesLocation(loc location) bool {
+	if loc.BlockType == "module" && e.modules[loc.Address()] {
+		return true
+	}
+
+	resourceType := loc.ResourceType()
+	if e.resourceTypes[resourceType] {
+		return true
+	}
+
+	if len(loc.Path) == 0 {
+		return false
+	}
+
+	for _, pattern := range e.attributes {
+		if matchPathPrefix(pattern, loc.Path) {
+			return true
+		}
+		if resourceType != "" && matchPathPrefix(pattern, append([]string{resourceType}, loc.Path...)) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchPathPrefix reports whether the pattern segments match the leading
+// segments of the path, so "tags.*" matches "tags.Name" and anything below it
+func matchPathPrefix(pattern, segments []string) bool {
+	if len(pattern) > len(segments) {
+		return false
+	}
+
+	for i, p := range pattern {
+		if ok, _ := path.Match(p, segments[i]); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// compileGlob turns a file glob into a regular expression. "*" and "?" match
+// within a single path segment, "**" matches any number of segments.
+func compileGlob(pattern string) (*regexp.Regexp, error) {
+	pattern = filepath.ToSlash(pattern)
+
+	var sb strings.Builder
+	sb.WriteString("^")
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; c {
+		case '*':
+			if i+1 < len(pattern) && pattern[i+1] == '*' {
+				i++
+				if i+1 < len(pattern) && pattern[i+1] == '/' {
+					i++
+					sb.WriteString("(?:.*/)?")
+				} else {
+					sb.WriteString(".*")
+				}
+			} else {
+				sb.WriteString("[^/]*")
+			}
+		case '?':
+			sb.WriteString("[^/]")
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	sb.WriteString("$")
+
+	return regexp.Compile(sb.String())
+}
+
+// ruleConfig is the configuration accepted by the rule blocks of the ruleset
+type ruleConfig struct {
+	AllowedRegions           []string `hclext:"allowed_regions,optional"`
+	AllowedPartitions        []string `hclext:"allowed_partitions,optional"`
+	AllowedAccountIDs        []string `hclext:"allowed_account_ids,optional"`
+	AllowedAMIs              []string `hclext:"allowed_amis,optional"`
+	AllowedServicePrincipals []string `hclext:"allowed_service_principals,optional"`
+
+	// Severity sets the severity of the issues of a finding kind, overriding
+	// the severity of the rule (e.g. partition = "ERROR")
+	Severity map[string]string `hclext:"severity,optional"`
+
+	severities map[string]tflint.Severity
+}
+
+// decodeRuleConfig fetches the configuration of the given rule. Within a
+// check of the ruleset it is decoded once per rule.
+func decodeRuleConfig(runner tflint.Runner, rule tflint.Rule) (*ruleConfig, error) {
+	cr, cached := runner.(*checkRunner)
+	if cached {
+		if config, ok := cr.configs[rule.Name()]; ok {
+			return config, nil
+		}
+	}
+
+	config := &ruleConfig{}
+	if err := runner.DecodeRuleConfig(rule.Name(), config); err != nil {
+		return nil, err
+	}
+	severities, err := parseSeverities(config.Severity)
+	if err != nil {
+		return nil, fmt.Errorf("invalid severity in rule %q: %w", rule.Name(), err)
+	}
+	config.severities = severities
+
+	if cached {
+		cr.configs[rule.Name()] = config
+	}
+	return config, nil
+}
+
+// parseSeverities parses the severities set per finding kind
+func parseSeverities(names map[string]string) (map[string]tflint.Severity, error) {
+	severities := make(map[string]tflint.Severity, len(names))
+	for kind, name := range names {
+		if !slices.Contains(findingKinds, kind) {
+			return nil, fmt.Errorf("unknown finding kind %q, expected one of %s", kind, strings.Join(findingKinds, ", "))
+		}
+		switch strings.ToUpper(name) {
+		case "ERROR":
+			severities[kind] = tflint.ERROR
+		case "WARNING":
+			severities[kind] = tflint.WARNING
+		case "NOTICE":
+			severities[kind] = tflint.NOTICE
+		default:
+			return nil, fmt.Errorf("unknown severity %q for %s, expected ERROR, WARNING or NOTICE", name, kind)
+		}
+	}
+	return severities, nil
+}
+
+// severityRule is a rule whose issue is reported with another severity
+type severityRule struct {
+	tflint.Rule
+	severity tflint.Severity
+}
+
+// Severity returns the severity of the issue
+func (r *severityRule) Severity() tflint.Severity {
+	return r.severity
+}
+
+// issueRule returns the rule to report an issue of the kind with, which has
+// the severity configured for the kind
+func issueRule(runner tflint.Runner, rule tflint.Rule, kind string) (tflint.Rule, error) {
+	config, err := decodeRuleConfig(runner, rule)
+	if err != nil {
+		return nil, err
+	}
+	severity, ok := config.severities[kind]
+	if !ok || severity == rule.Severity() {
+		return rule, nil
+	}
+	return &severityRule{Rule: rule, severity: severity}, nil
+}
+
+// allows reports whether a hardcoded value of the given kind is allowlisted.
+// dns_suffix principals are allowed when an allowed principal is for the
+// same service.
+func (c *ruleConfig) allows(kind, value string) bool {
+	switch kind {
+	case kindRegion:
+		return slices.Contains(c.AllowedRegions, value)
+	case kindPartition:
+		return slices.Contains(c.AllowedPartitions, value)
+	case kindAccountID:
+		return slices.Contains(c.AllowedAccountIDs, value)
+	case kindAMIID:
+		return slices.Contains(c.AllowedAMIs, value)
+	case kindServicePrincipal:
+		return slices.Contains(c.AllowedServicePrincipals, value)
+	case kindDNSSuffix:
+		for _, principal := range c.AllowedServicePrincipals {
+			if service, _, _ := strings.Cut(principal, "."); service == value {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// allowsMatch reports whether a match is allowlisted. Availability zones are
+// allowed when their region is.
+func (c *ruleConfig) allowsMatch(match Match) bool {
+	if match.Kind == kindAvailabilityZone {
+		return slices.Contains(c.AllowedRegions, match.Region)
+	}
+	return c.allows(match.Kind, match.Value)
+}
@@ -0,0 +1,149 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+func Test_CompileGlob(t *testing.T) {
+	tests := []struct {
+		Pattern  string
+		Filename string
+		Expected bool
+	}{
+		{"generated/*.tf", "generated/main.tf", true},
+		{"generated/*.tf", "generated/nested/main.tf", false},
+		{"generated/**", "generated/nested/main.tf", true},
+		{"**/generated_*.tf", "generated_providers.tf", true},
+		{"**/generated_*.tf", "modules/vpc/generated_providers.tf", true},
+		{"main.tf", "modules/main.tf", false},
+		{"?.tf", "a.tf", true},
+	}
+
+	for _, test := range tests {
+		re, err := compileGlob(test.Pattern)
+		if err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+		if got := re.MatchString(test.Filename); got != test.Expected {
+			t.Errorf("Pattern %q against %q: expected %v, got %v", test.Pattern, test.Filename, test.Expected, got)
+		}
+	}
+}
+
+func Test_RuleSeverityPerKind(t *testing.T) {
+	content := `
+resource "aws_instance" "test" {
+  availability_zone = "us-east-1a"
+  tags = {
+    Region = "us-east-1"
+    Arn    = "arn:aws:sns:${var.region}:${var.account}:topic"
+  }
+}`
+
+	tests := []struct {
+		Name     string
+		Config   string
+		Expected map[string]tflint.Severity
+	}{
+		{
+			Name: "rule severity",
+			Config: `
+rule "aws_meta_hardcoded" {
+  enabled = true
+}`,
+			Expected: map[string]tflint.Severity{
+				"us-east-1a": tflint.WARNING,
+				"us-east-1":  tflint.WARNING,
+				"aws":        tflint.WARNING,
+			},
+		},
+		{
+			Name: "severity per kind",
+			Config: `
+rule "aws_meta_hardcoded" {
+  enabled  = true
+  severity = {
+    partition         = "ERROR"
+    availability_zone = "notice"
+  }
+}`,
+			Expected: map[string]tflint.Severity{
+				"us-east-1a": tflint.NOTICE,
+				"us-east-1":  tflint.WARNING,
+				"aws":        tflint.ERROR,
+			},
+		},
+	}
+
+	rule := NewAwsMetaHardcodedRule()
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			runner := helper.TestRunner(t, map[string]string{
+				"main.tf":     content,
+				".tflint.hcl": test.Config,
+			})
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			if len(runner.Issues) != len(test.Expected) {
+				t.Fatalf("Expected %d issues, got %d", len(test.Expected), len(runner.Issues))
+			}
+			for _, issue := range runner.Issues {
+				text := string(issue.Range.SliceBytes([]byte(content)))
+				expected, ok := test.Expected[text]
+				if !ok {
+					t.Errorf("Unexpected issue for '%s'", text)
+					continue
+				}
+				if got := issue.Rule.Severity(); got != expected {
+					t.Errorf("Expected %s for '%s', got %s", expected, text, got)
+				}
+			}
+		})
+	}
+}
+
+func Test_RuleSeverityInvalid(t *testing.T) {
+	tests := []struct {
+		Name   string
+		Config string
+	}{
+		{
+			Name: "unknown kind",
+			Config: `
+rule "aws_meta_hardcoded" {
+  enabled  = true
+  severity = { az = "NOTICE" }
+}`,
+		},
+		{
+			Name: "unknown severity",
+			Config: `
+rule "aws_meta_hardcoded" {
+  enabled  = true
+  severity = { partition = "CRITICAL" }
+}`,
+		},
+	}
+
+	rule := NewAwsMetaHardcodedRule()
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			runner := helper.TestRunner(t, map[string]string{
+				"main.tf":     `resource "aws_instance" "test" {}`,
+				".tflint.hcl": test.Config,
+			})
+
+			if err := rule.Check(runner); err == nil {
+				t.Fatal("Expected an error, got none")
+			}
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+// definition is a local value or the default of a variable. A hardcoded value
+// it holds is reported where it is defined rather than everywhere it is used.
+type definition struct {
+	Expr hcl.Expression
+
+	// References are the definitions Expr refers to
+	References []string
+
+	// Uses is the number of references to the definition in the module
+	Uses int
+}
+
+// moduleDefinitions returns the locals and variable defaults of a module by
+// the reference they are used with, such as "local.region" or "var.region".
+// Only native syntax files are looked into.
+func moduleDefinitions(files map[string]*hcl.File) map[string]*definition {
+	defs := make(map[string]*definition)
+	for _, file := range files {
+		body, ok := file.Body.(*hclsyntax.Body)
+		if !ok {
+			continue
+		}
+		for _, block := range body.Blocks {
+			switch {
+			case block.Type == "locals":
+				for name, attr := range block.Body.Attributes {
+					defs["local."+name] = &definition{Expr: attr.Expr}
+				}
+			case block.Type == "variable" && len(block.Labels) == 1:
+				if attr, ok := block.Body.Attributes["default"]; ok {
+					defs["var."+block.Labels[0]] = &definition{Expr: attr.Expr}
+				}
+			}
+		}
+	}
+
+	for _, def := range defs {
+		def.References = definitionReferences(defs, def.Expr)
+	}
+	for _, file := range files {
+		body, ok := file.Body.(*hclsyntax.Body)
+		if !ok {
+			continue
+		}
+		hclsyntax.VisitAll(body, func(node hclsyntax.Node) hcl.Diagnostics {
+			if expr, ok := node.(*hclsyntax.ScopeTraversalExpr); ok {
+				if def, ok := defs[referenceKey(expr.Traversal)]; ok {
+					def.Uses++
+				}
+			}
+			return nil
+		})
+	}
+
+	return defs
+}
+
+// definitionReferences returns the definitions expr refers to
+func definitionReferences(defs map[string]*definition, expr hcl.Expression) []string {
+	var refs []string
+	seen := make(map[string]bool)
+	for _, traversal := range expr.Variables() {
+		key := referenceKey(traversal)
+		if _, ok := defs[key]; ok && !seen[key] {
+			seen[key] = true
+			refs = append(refs, key)
+		}
+	}
+	return refs
+}
+
+// referenceKey returns the local or variable a traversal starts with, such as
+// "local.region" for local.region or "var.tags" for var.tags["Name"]
+func referenceKey(traversal hcl.Traversal) string {
+	if len(traversal) < 2 {
+		return ""
+	}
+	root := traversal.RootName()
+	if root != "local" && root != "var" {
+		return ""
+	}
+	attr, ok := traversal[1].(hcl.TraverseAttr)
+	if !ok {
+		return ""
+	}
+	return root + "." + attr.Name
+}
+
+// traceFindings reports the hardcoded values of locals and variable defaults
+// once, at their definition. A finding whose value comes from the definitions
+// it refers to, directly or through other definitions, is dropped when one of
+// them holds the same value. Findings at a definition that is used elsewhere
+// note how many times it is used.
+func traceFindings(findings []finding, defs map[string]*definition) []finding {
+	type findingKey struct{ kind, value string }
+
+	// The values written in each definition
+	held := make(map[string]map[findingKey]bool)
+	uses := make([]int, len(findings))
+	for i, f := range findings {
+		for key, def := range defs {
+			if !rangeContains(def.Expr.Range(), f.Range) {
+				continue
+			}
+			uses[i] = def.Uses
+			if len(f.References) == 0 {
+				if held[key] == nil {
+					held[key] = make(map[findingKey]bool)
+				}
+				held[key][findingKey{f.Kind, f.Value}] = true
+			}
+		}
+	}
+
+	var result []finding
+	for i, f := range findings {
+		if len(f.References) > 0 && reachesValue(defs, f.References, func(key string) bool {
+			return held[key][findingKey{f.Kind, f.Value}]
+		}) {
+			continue
+		}
+
+		switch {
+		case uses[i] == 1:
+			f.Message += " (used at 1 location)"
+		case uses[i] > 1:
+			f.Message += fmt.Sprintf(" (used at %d locations)", uses[i])
+		}
+		result = append(result, f)
+	}
+	return result
+}
+
+// reachesValue reports whether holds is true for any definition reachable
+// from refs
+func reachesValue(defs map[string]*definition, refs []string, holds func(key string) bool) bool {
+	visited := make(map[string]bool)
+	for len(refs) > 0 {
+		key := refs[len(refs)-1]
+		refs = refs[:len(refs)-1]
+		if visited[key] {
+			continue
+		}
+		visited[key] = true
+
+		if holds(key) {
+			return true
+		}
+		if def, ok := defs[key]; ok {
+			refs = append(refs, def.References...)
+		}
+	}
+	return false
+}
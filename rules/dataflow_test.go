@@ -0,0 +1,82 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_TraceFindingsToDefinitions(t *testing.T) {
+	content := `
+variable "region" {
+  default = "us-east-1"
+}
+
+locals {
+  zone  = "eu-west-2a"
+  queue = "arn:aws:sqs:${var.region}:123456789012:jobs"
+}
+
+resource "aws_instance" "a" {
+  availability_zone = local.zone
+  tags = {
+    Region = var.region
+    Queue  = local.queue
+  }
+}
+
+resource "aws_instance" "b" {
+  availability_zone = local.zone
+  tags = {
+    Zone = "${local.zone}"
+    Own  = "eu-west-2a"
+  }
+}`
+
+	rule := NewAwsMetaHardcodedRule()
+	runner := helper.TestRunner(t, map[string]string{"main.tf": content})
+
+	if err := rule.Check(runner); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	helper.AssertIssues(t, helper.Issues{
+		{
+			Rule:    rule,
+			Message: "[AWSMETA001 kind=region value=us-east-1 address=var.region replacement=data.aws_region.current.name] Hardcoded AWS region 'us-east-1' found. Consider using data.aws_region.current.name (used at 2 locations)",
+			Range: hcl.Range{
+				Filename: "main.tf",
+				Start:    hcl.Pos{Line: 3, Column: 14},
+				End:      hcl.Pos{Line: 3, Column: 23},
+			},
+		},
+		{
+			Rule:    rule,
+			Message: "[AWSMETA003 kind=availability_zone value=eu-west-2a address=local.zone replacement=data.aws_availability_zones.available.names] Hardcoded AWS availability zone 'eu-west-2a' found. Consider using data.aws_availability_zones to dynamically fetch AZs for the current region (used at 3 locations)",
+			Range: hcl.Range{
+				Filename: "main.tf",
+				Start:    hcl.Pos{Line: 7, Column: 12},
+				End:      hcl.Pos{Line: 7, Column: 22},
+			},
+		},
+		{
+			Rule:    rule,
+			Message: "[AWSMETA002 kind=partition value=aws address=local.queue replacement=data.aws_partition.current.partition] Hardcoded AWS partition 'aws' found in ARN. Consider using data.aws_partition.current.partition (used at 1 location)",
+			Range: hcl.Range{
+				Filename: "main.tf",
+				Start:    hcl.Pos{Line: 8, Column: 16},
+				End:      hcl.Pos{Line: 8, Column: 19},
+			},
+		},
+		{
+			Rule:    rule,
+			Message: "[AWSMETA003 kind=availability_zone value=eu-west-2a address=aws_instance.b replacement=data.aws_availability_zones.available.names] Hardcoded AWS availability zone 'eu-west-2a' found. Consider using data.aws_availability_zones to dynamically fetch AZs for the current region",
+			Range: hcl.Range{
+				Filename: "main.tf",
+				Start:    hcl.Pos{Line: 23, Column: 13},
+				End:      hcl.Pos{Line: 23, Column: 23},
+			},
+		},
+	}, runner.Issues)
+}
@@ -0,0 +1,43 @@
+package rules
+
+import (
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// emitIssue reports the match unless an annotation allows it or the baseline
+// holds it. The issue has the severity configured for the kind, and its
+// message starts with the code and fields of the finding.
+func emitIssue(runner tflint.Runner, rule tflint.Rule, match Match, rng hcl.Range) error {
+	return emitFinding(runner, rule, match, rng, nil)
+}
+
+// emitIssueWithFix is emitIssue for an issue that comes with a fix
+func emitIssueWithFix(runner tflint.Runner, rule tflint.Rule, match Match, rng hcl.Range, fixFunc func(tflint.Fixer) error) error {
+	return emitFinding(runner, rule, match, rng, fixFunc)
+}
+
+func emitFinding(runner tflint.Runner, rule tflint.Rule, match Match, rng hcl.Range, fixFunc func(tflint.Fixer) error) error {
+	message, allowed, err := allowIssue(runner, match, rng)
+	if err != nil || allowed {
+		return err
+	}
+	if known, err := baselined(runner, rule, match, rng); err != nil || known {
+		return err
+	}
+	rule, err = issueRule(runner, rule, match.Kind)
+	if err != nil {
+		return err
+	}
+
+	files, err := runnerFiles(runner)
+	if err != nil {
+		return err
+	}
+	message = findingMessage(match, issueAddress(locate(files, rng), rng), message)
+
+	if fixFunc == nil {
+		return runner.EmitIssue(rule, message, rng)
+	}
+	return runner.EmitIssueWithFix(rule, message, rng, fixFunc)
+}
@@ -0,0 +1,51 @@
+package rules
+
+import (
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+)
+
+// localValue returns the value of an expression that refers to nothing, such
+// as a literal or a template made only of literals. These are evaluated in
+// the plugin, saving a gRPC round-trip to TFLint. Expressions that refer to
+// variables, locals, resources or functions are not.
+func localValue(expr hcl.Expression) (cty.Value, bool) {
+	if len(expr.Variables()) > 0 {
+		return cty.NilVal, false
+	}
+	value, diags := expr.Value(nil)
+	if diags.HasErrors() {
+		return cty.NilVal, false
+	}
+	return value, true
+}
+
+// evaluateString calls fn with the string value of expr, like
+// runner.EvaluateExpr with a string callback. fn is not called when the value
+// is null or not known yet, and a value that isn't a string is an error.
+func evaluateString(runner tflint.Runner, expr hcl.Expression, fn func(string) error) error {
+	value, ok := localValue(expr)
+	if !ok {
+		return runner.EvaluateExpr(expr, fn, nil)
+	}
+
+	if value.IsNull() || !value.IsWhollyKnown() {
+		return nil
+	}
+	str, err := convert.Convert(value, cty.String)
+	if err != nil {
+		return err
+	}
+	return fn(str.AsString())
+}
+
+// evaluateValue calls fn with the value of expr, like runner.EvaluateExpr with
+// a cty.Value callback, which is also called with unknown values
+func evaluateValue(runner tflint.Runner, expr hcl.Expression, fn func(cty.Value) error) error {
+	if value, ok := localValue(expr); ok {
+		return fn(value)
+	}
+	return runner.EvaluateExpr(expr, fn, nil)
+}
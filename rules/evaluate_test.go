@@ -0,0 +1,90 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_EvaluateString(t *testing.T) {
+	tests := []struct {
+		Name        string
+		Expr        string
+		Expected    []string
+		Error       bool
+		Evaluations int
+	}{
+		{
+			Name:     "string literal",
+			Expr:     `"eu-west-1"`,
+			Expected: []string{"eu-west-1"},
+		},
+		{
+			Name:     "template of literals",
+			Expr:     `"arn:aws:s3:::${"bucket"}/*"`,
+			Expected: []string{"arn:aws:s3:::bucket/*"},
+		},
+		{
+			Name:     "heredoc",
+			Expr:     "<<EOT\neu-west-1\nEOT\n",
+			Expected: []string{"eu-west-1\n"},
+		},
+		{
+			Name:     "number literal",
+			Expr:     `123456789012`,
+			Expected: []string{"123456789012"},
+		},
+		{
+			Name: "null",
+			Expr: `null`,
+		},
+		{
+			Name:  "tuple",
+			Expr:  `["eu-west-1"]`,
+			Error: true,
+		},
+		{
+			Name:        "variable",
+			Expr:        `"arn:aws:s3:${var.region}:123456789012:bucket"`,
+			Expected:    []string{"arn:aws:s3:eu-west-1:123456789012:bucket"},
+			Evaluations: 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			content := `
+variable "region" {
+  default = "eu-west-1"
+}
+
+locals {
+  value = ` + test.Expr + `
+}`
+			runner := &countingRunner{Runner: helper.TestRunner(t, map[string]string{"main.tf": content})}
+
+			files, err := runner.GetFiles()
+			if err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+			blocks := files["main.tf"].Body.(*hclsyntax.Body).Blocks
+			expr := blocks[len(blocks)-1].Body.Attributes["value"].Expr
+
+			var got []string
+			err = evaluateString(runner, expr, func(value string) error {
+				got = append(got, value)
+				return nil
+			})
+			if test.Error != (err != nil) {
+				t.Fatalf("Expected error %v, got %v", test.Error, err)
+			}
+			if len(got) != len(test.Expected) || (len(got) > 0 && got[0] != test.Expected[0]) {
+				t.Errorf("Expected %q, got %q", test.Expected, got)
+			}
+			if runner.evaluations != test.Evaluations {
+				t.Errorf("Expected %d evaluations, got %d", test.Evaluations, runner.evaluations)
+			}
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package rules
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// externalText is the text of a file read by a file() or templatefile() call
+type externalText struct {
+	// Path is the path of the file, relative to the working directory like
+	// the filenames of the module
+	Path string
+
+	// Call is the range of the call, where issues found in the file are reported
+	Call hcl.Range
+
+	// Text is the content of the file. A template is rendered with its
+	// variables as placeholders, as their values are not known here.
+	Text string
+
+	// File holds the content of the file, for looking up the source of Literals
+	File *hcl.File
+
+	// Literals are the parts of Text read verbatim from the file
+	Literals []templateLiteral
+}
+
+// readExternalText reads the file behind a file() or templatefile() call.
+// Only literal paths are followed, which may start with ${path.module} or
+// ${path.root}. Files that can't be read are left to Terraform to report.
+func readExternalText(expr hcl.Expression) (*externalText, bool) {
+	call, ok := expr.(*hclsyntax.FunctionCallExpr)
+	if !ok || len(call.Args) == 0 || call.ExpandFinal {
+		return nil, false
+	}
+	if call.Name != "file" && call.Name != "templatefile" {
+		return nil, false
+	}
+
+	path, ok := externalPath(call.Args[0], filepath.Dir(call.Range().Filename))
+	if !ok {
+		return nil, false
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+
+	text := &externalText{
+		Path: path,
+		Call: call.Range(),
+		Text: string(content),
+		File: &hcl.File{Bytes: content},
+	}
+
+	if call.Name == "file" {
+		text.Literals = []templateLiteral{{
+			Value: text.Text,
+			Expr: &hclsyntax.LiteralValueExpr{
+				Val:      cty.StringVal(text.Text),
+				SrcRange: hcl.Range{Filename: path, Start: hcl.InitialPos, End: advancePos(hcl.InitialPos, text.Text)},
+			},
+		}}
+		return text, true
+	}
+
+	template, diags := hclsyntax.ParseTemplate(content, path, hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, false
+	}
+	tmpl, ok := template.(*hclsyntax.TemplateExpr)
+	if !ok {
+		// A template made of a single interpolation has no text to check
+		return nil, false
+	}
+	rendered, literals := renderTemplate(nil, text.files(), tmpl)
+	text.Text = rendered
+	text.Literals = literals
+	return text, true
+}
+
+// externalPath returns the file path written by a file() or templatefile()
+// argument. dir is the directory of the module, which path.module refers to.
+func externalPath(expr hcl.Expression, dir string) (string, bool) {
+	var parts []hclsyntax.Expression
+	switch e := expr.(type) {
+	case *hclsyntax.TemplateExpr:
+		parts = e.Parts
+	case *hclsyntax.TemplateWrapExpr:
+		parts = []hclsyntax.Expression{e.Wrapped}
+	case *hclsyntax.LiteralValueExpr:
+		parts = []hclsyntax.Expression{e}
+	default:
+		return "", false
+	}
+
+	path := ""
+	for _, part := range parts {
+		switch p := part.(type) {
+		case *hclsyntax.LiteralValueExpr:
+			if p.Val.Type() != cty.String {
+				return "", false
+			}
+			path += p.Val.AsString()
+		case *hclsyntax.ScopeTraversalExpr:
+			if len(p.Traversal) != 2 || p.Traversal.RootName() != "path" {
+				return "", false
+			}
+			attr, ok := p.Traversal[1].(hcl.TraverseAttr)
+			switch {
+			case ok && attr.Name == "module":
+				path += dir
+			case ok && attr.Name == "root":
+				path += "."
+			default:
+				return "", false
+			}
+		default:
+			return "", false
+		}
+	}
+
+	if path == "" {
+		return "", false
+	}
+	return filepath.Clean(path), true
+}
+
+// files returns the file map under which the source of Literals is found
+func (t *externalText) files() map[string]*hcl.File {
+	return map[string]*hcl.File{t.Path: t.File}
+}
+
+// line returns the line of the file that Text[offset] was read from, or 0
+// when it was rendered from a template interpolation
+func (t *externalText) line(offset int) int {
+	for _, literal := range t.Literals {
+		if offset < literal.Start || offset >= literal.Start+len(literal.Value) {
+			continue
+		}
+		start := literal.Expr.SrcRange.Start
+		if src, ok := sourceText(t.files(), literal.Expr.SrcRange); ok && src == literal.Value {
+			return advancePos(start, literal.Value[:offset-literal.Start]).Line
+		}
+		return start.Line
+	}
+	return 0
+}
+
+// message adds the file, and the line when known, to the message of an issue
+// found in the file
+func (t *externalText) message(message string, line int) string {
+	if line == 0 {
+		return fmt.Sprintf("%s (in %s)", message, t.Path)
+	}
+	return fmt.Sprintf("%s (in %s line %d)", message, t.Path, line)
+}
@@ -0,0 +1,110 @@
+package rules
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_ReadExternalText(t *testing.T) {
+	t.Chdir(t.TempDir())
+	if err := os.MkdirAll("modules/app", 0o755); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"policy.json":                 "{\n  \"Resource\": \"arn:aws:s3:::logs\"\n}\n",
+		"modules/app/policy.json.tpl": "{\n  \"Resource\": \"arn:aws:s3:::${bucket}\",\n  \"Region\": \"eu-west-1\"\n}\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(name, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		Name     string
+		Filename string
+		Expr     string
+		Path     string
+		Text     string
+		Offset   int
+		Line     int
+	}{
+		{
+			Name:     "file() relative to the working directory",
+			Filename: "main.tf",
+			Expr:     `file("policy.json")`,
+			Path:     "policy.json",
+			Text:     files["policy.json"],
+			Offset:   16,
+			Line:     2,
+		},
+		{
+			Name:     "templatefile() relative to path.module",
+			Filename: "modules/app/main.tf",
+			Expr:     `templatefile("${path.module}/policy.json.tpl", { bucket = "logs" })`,
+			Path:     filepath.Join("modules", "app", "policy.json.tpl"),
+			Text:     "{\n  \"Resource\": \"arn:aws:s3:::${}\",\n  \"Region\": \"eu-west-1\"\n}\n",
+			Offset:   48,
+			Line:     3,
+		},
+		{
+			Name:     "template variables have no line",
+			Filename: "modules/app/main.tf",
+			Expr:     `templatefile("${path.module}/policy.json.tpl", {})`,
+			Path:     filepath.Join("modules", "app", "policy.json.tpl"),
+			Text:     "{\n  \"Resource\": \"arn:aws:s3:::${}\",\n  \"Region\": \"eu-west-1\"\n}\n",
+			Offset:   30,
+			Line:     0,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			expr := parseTestExpression(t, test.Filename, test.Expr)
+
+			text, ok := readExternalText(expr)
+			if !ok {
+				t.Fatal("Expected the file to be read")
+			}
+			if text.Path != test.Path {
+				t.Errorf("Expected path %s, got %s", test.Path, text.Path)
+			}
+			if text.Text != test.Text {
+				t.Errorf("Expected text %q, got %q", test.Text, text.Text)
+			}
+			if line := text.line(test.Offset); line != test.Line {
+				t.Errorf("Expected line %d, got %d", test.Line, line)
+			}
+			if text.Call != expr.Range() {
+				t.Errorf("Expected issues to be reported on the call")
+			}
+		})
+	}
+
+	for _, src := range []string{
+		`file("missing.json")`,
+		`file(var.policy_path)`,
+		`file("${path.cwd}/policy.json")`,
+		`filebase64("policy.json")`,
+	} {
+		if _, ok := readExternalText(parseTestExpression(t, "main.tf", src)); ok {
+			t.Errorf("Expected %s not to be read", src)
+		}
+	}
+}
+
+func parseTestExpression(t *testing.T, filename, src string) hcl.Expression {
+	t.Helper()
+
+	runner := helper.TestRunner(t, map[string]string{filename: "policy = " + src})
+	file, err := runner.GetFile(filename)
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	attributes, _ := file.Body.JustAttributes()
+	return attributes["policy"].Expr
+}
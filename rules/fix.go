@@ -0,0 +1,212 @@
+package rules
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// fixContext holds what a fixableRule needs to build the fixes of one check
+type fixContext struct {
+	config *ruleConfig
+	files  map[string]*hcl.File
+	data   *dataScaffold
+
+	// accounts is the configuration of aws_hardcoded_ids, the rule that
+	// reports account IDs
+	accounts *ruleConfig
+}
+
+func newFixContext(files map[string]*hcl.File, config, accounts *ruleConfig) *fixContext {
+	return &fixContext{
+		config:   config,
+		files:    files,
+		data:     newDataScaffold(files),
+		accounts: accounts,
+	}
+}
+
+// allowsAccount reports whether a fix leaves the account ID in place, because
+// the rule being fixed or aws_hardcoded_ids allows it
+func (c *fixContext) allowsAccount(account string) bool {
+	return c.config.allows(kindAccountID, account) || c.accounts.allows(kindAccountID, account)
+}
+
+// fixable reports whether a finding at rng can be replaced by a reference.
+// Variable defaults can't refer to anything, so their findings are reported
+// without a fix.
+func (c *fixContext) fixable(rng hcl.Range) bool {
+	return locate(c.files, rng).BlockType != "variable"
+}
+
+// quotedRange returns the range of the quoted string whose whole content is
+// the given range, so the string can be replaced by a reference. It reports
+// false when the range is not directly enclosed in quotes.
+func (c *fixContext) quotedRange(rng hcl.Range) (hcl.Range, bool) {
+	file, ok := c.files[rng.Filename]
+	if !ok {
+		return rng, false
+	}
+
+	src := file.Bytes
+	if rng.Start.Byte < 1 || rng.End.Byte >= len(src) || src[rng.Start.Byte-1] != '"' || src[rng.End.Byte] != '"' {
+		return rng, false
+	}
+
+	rng.Start.Byte--
+	rng.Start.Column--
+	rng.End.Byte++
+	rng.End.Column++
+	return rng, true
+}
+
+// Names of the data sources that fixed references point to
+const (
+	partitionDataSource        = "aws_partition"
+	regionDataSource           = "aws_region"
+	callerIdentityDataSource   = "aws_caller_identity"
+	servicePrincipalDataSource = "aws_service_principal"
+)
+
+// scaffoldFilenames are the files that new data blocks go to, in order of preference
+var scaffoldFilenames = []string{"aws_meta_data.tf", "data.tf"}
+
+// dataScaffold adds the data blocks that fixed references depend on, when
+// the module does not declare them yet.
+//
+// TFLint cannot create files while fixing, so new blocks go to an existing
+// aws_meta_data.tf or data.tf, then to a file that already declares data
+// blocks, and otherwise to the file being fixed.
+//
+// TFLint discards the fix of an issue that is ignored or not applied, so
+// blocks added by earlier fixes are looked up in the fixer rather than
+// remembered here.
+type dataScaffold struct {
+	files  map[string]*hcl.File
+	target string
+
+	// declared are the data blocks of the module before fixing
+	declared map[string]bool
+}
+
+// dataBlockSchema is the schema of data blocks
+var dataBlockSchema = &hcl.BodySchema{
+	Blocks: []hcl.BlockHeaderSchema{{Type: "data", LabelNames: []string{"type", "name"}}},
+}
+
+func newDataScaffold(files map[string]*hcl.File) *dataScaffold {
+	s := &dataScaffold{
+		files:    files,
+		declared: make(map[string]bool),
+	}
+
+	filenames := make([]string, 0, len(files))
+	for filename := range files {
+		filenames = append(filenames, filename)
+	}
+	sort.Strings(filenames)
+
+	var withData string
+	for _, filename := range filenames {
+		for _, address := range dataAddresses(files[filename].Body) {
+			s.declared[address] = true
+			if withData == "" && strings.HasSuffix(filename, ".tf") {
+				withData = filename
+			}
+		}
+	}
+
+	for _, name := range scaffoldFilenames {
+		for _, filename := range filenames {
+			if filepath.Base(filename) == name {
+				s.target = filename
+				return s
+			}
+		}
+	}
+	s.target = withData
+
+	return s
+}
+
+func dataAddress(dataType, name string) string {
+	return fmt.Sprintf("data.%s.%s", dataType, name)
+}
+
+// dataAddresses returns the addresses of the data blocks in a body
+func dataAddresses(body hcl.Body) []string {
+	content, _, _ := body.PartialContent(dataBlockSchema)
+	if content == nil {
+		return nil
+	}
+	addresses := make([]string, len(content.Blocks))
+	for i, block := range content.Blocks {
+		addresses[i] = dataAddress(block.Labels[0], block.Labels[1])
+	}
+	return addresses
+}
+
+// fixerChanges is implemented by the SDK's fixer, which holds the content of
+// the files changed by the fixes that TFLint has kept so far
+type fixerChanges interface {
+	Changes() map[string][]byte
+}
+
+// changed returns the content of a file with the changes of the fixer
+func changed(fixer tflint.Fixer, file *hcl.File, filename string) []byte {
+	if f, ok := fixer.(fixerChanges); ok {
+		if src, ok := f.Changes()[filename]; ok {
+			return src
+		}
+	}
+	return file.Bytes
+}
+
+// ensure adds a data block of the given type and name unless the module
+// already declares it, or an earlier fix has added it. filename is the file
+// being fixed, used when there is no better place for the block. body holds
+// the block's arguments, if any.
+func (s *dataScaffold) ensure(fixer tflint.Fixer, filename, dataType, name, body string) error {
+	address := dataAddress(dataType, name)
+	if s.declared[address] {
+		return nil
+	}
+
+	target := s.target
+	if target == "" {
+		target = filename
+	}
+	file, ok := s.files[target]
+	if !ok {
+		return fmt.Errorf("file not found: %s", target)
+	}
+
+	src := changed(fixer, file, target)
+	if !bytes.Equal(src, file.Bytes) {
+		parsed, _ := hclsyntax.ParseConfig(src, target, hcl.InitialPos)
+		if slices.Contains(dataAddresses(parsed.Body), address) {
+			return nil
+		}
+	}
+
+	block := fmt.Sprintf("data %q %q {}\n", dataType, name)
+	if body != "" {
+		block = fmt.Sprintf("data %q %q {\n%s}\n", dataType, name, body)
+	}
+	if len(src) > 0 {
+		block = "\n" + block
+		if src[len(src)-1] != '\n' {
+			block = "\n" + block
+		}
+	}
+
+	end := advancePos(hcl.Pos{Line: 1, Column: 1}, string(file.Bytes))
+	return fixer.InsertTextAfter(hcl.Range{Filename: target, Start: end, End: end}, block)
+}
@@ -0,0 +1,91 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// discardingRunner discards the fix of the first issue emitted with one, as
+// TFLint does when the issue is ignored by an annotation or not applied
+type discardingRunner struct {
+	*helper.Runner
+	discarded bool
+}
+
+func (r *discardingRunner) EmitIssueWithFix(rule tflint.Rule, message string, rng hcl.Range, fixFunc func(tflint.Fixer) error) error {
+	if r.discarded {
+		return r.Runner.EmitIssueWithFix(rule, message, rng, fixFunc)
+	}
+	r.discarded = true
+	if err := fixFunc(discardFixer{}); err != nil {
+		return err
+	}
+	return r.Runner.EmitIssue(rule, message, rng)
+}
+
+// discardFixer is a fixer whose changes go nowhere
+type discardFixer struct {
+	tflint.Fixer
+}
+
+func (discardFixer) ReplaceText(hcl.Range, ...any) error      { return nil }
+func (discardFixer) InsertTextAfter(hcl.Range, string) error  { return nil }
+func (discardFixer) InsertTextBefore(hcl.Range, string) error { return nil }
+
+func Test_DataScaffoldTarget(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Files    map[string]string
+		Expected string
+	}{
+		{
+			Name: "aws_meta_data.tf is preferred",
+			Files: map[string]string{
+				"main.tf":          `data "aws_region" "current" {}`,
+				"data.tf":          ``,
+				"aws_meta_data.tf": ``,
+			},
+			Expected: "aws_meta_data.tf",
+		},
+		{
+			Name: "data.tf",
+			Files: map[string]string{
+				"main.tf": `data "aws_region" "current" {}`,
+				"data.tf": ``,
+			},
+			Expected: "data.tf",
+		},
+		{
+			Name: "file with data blocks",
+			Files: map[string]string{
+				"main.tf":    `resource "aws_instance" "web" {}`,
+				"lookups.tf": `data "aws_region" "current" {}`,
+			},
+			Expected: "lookups.tf",
+		},
+		{
+			Name: "no data blocks",
+			Files: map[string]string{
+				"main.tf": `resource "aws_instance" "web" {}`,
+			},
+			Expected: "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			runner := helper.TestRunner(t, test.Files)
+			files, err := runner.GetFiles()
+			if err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			if got := newDataScaffold(files).target; got != test.Expected {
+				t.Errorf("Expected target %q, got %q", test.Expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,329 @@
+package rules
+
+import (
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/myerscode/tflint-ruleset-aws-meta/rules/awsmeta"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// policyRegion is a hardcoded region found in the text of an IAM policy
+type policyRegion struct {
+	Region string
+	InARN  bool
+
+	// Start and End are the byte range of the region in the searched text
+	Start int
+	End   int
+
+	// Value is the policy document value holding the region, if the policy
+	// was parsed as a document
+	Value *awsmeta.PolicyString
+}
+
+// findPolicyRegions returns every hardcoded region in the policy text, noting
+// whether it sits in the region field of an ARN
+func findPolicyRegions(matcher *awsmeta.Matcher, policy string) []policyRegion {
+	var regions []policyRegion
+	for _, hit := range matcher.Find(policy) {
+		if hit.Kind != awsmeta.HitRegion {
+			continue
+		}
+		regions = append(regions, policyRegion{
+			Region: hit.Value,
+			InARN:  hit.InARN,
+			Start:  hit.Start,
+			End:    hit.End,
+		})
+	}
+	return regions
+}
+
+// findDocumentRegions returns every hardcoded region in the values of the
+// policy document. Labels such as a Sid are not looked at.
+func findDocumentRegions(matcher *awsmeta.Matcher, policy *awsmeta.Policy) []policyRegion {
+	var regions []policyRegion
+	for _, value := range policy.Values() {
+		for _, match := range findPolicyRegions(matcher, value.Value) {
+			match.Value = value
+			regions = append(regions, match)
+		}
+	}
+	return regions
+}
+
+// policyPartition is a hardcoded partition found in an ARN of an IAM policy
+type policyPartition struct {
+	Partition string
+	Start     int
+	End       int
+	Value     *awsmeta.PolicyString
+}
+
+// findPolicyPartitions returns the partition of every ARN in the policy text
+func findPolicyPartitions(matcher *awsmeta.Matcher, policy string) []policyPartition {
+	var partitions []policyPartition
+	for _, hit := range matcher.Find(policy) {
+		if hit.Kind == awsmeta.HitPartition {
+			partitions = append(partitions, policyPartition{
+				Partition: hit.Value,
+				Start:     hit.Start,
+				End:       hit.End,
+			})
+		}
+	}
+	return partitions
+}
+
+// findDocumentPartitions returns the partition of every ARN in the values of
+// the policy document
+func findDocumentPartitions(matcher *awsmeta.Matcher, policy *awsmeta.Policy) []policyPartition {
+	var partitions []policyPartition
+	for _, value := range policy.Values() {
+		for _, match := range findPolicyPartitions(matcher, value.Value) {
+			match.Value = value
+			partitions = append(partitions, match)
+		}
+	}
+	return partitions
+}
+
+// evaluatePolicy calls fn with the text of the policy held by expr. The
+// policy of a jsonencode() call is encoded from the syntax of its argument
+// instead, and a template, such as a heredoc with interpolations, is rendered
+// part by part. That way a value that is not known yet, such as a variable,
+// doesn't hide the literal values around it. A policy read by file() or
+// templatefile() is checked in the file.
+func evaluatePolicy(runner tflint.Runner, files map[string]*hcl.File, expr hcl.Expression, fn func(policy string, source *policySource) error) error {
+	if arg, ok := jsonencodeArgument(expr); ok {
+		policy, leaves, err := encodeStaticJSON(runner, arg)
+		if err != nil {
+			return err
+		}
+
+		source := newPolicySource(files, expr, policy)
+		source.leaves = make(map[int]*policySource)
+		for _, leaf := range leaves {
+			source.leaves[leaf.Start] = newPolicySource(files, leaf.Expr, leaf.Value)
+		}
+		return fn(policy, source)
+	}
+
+	if text, ok := readExternalText(expr); ok {
+		source := &policySource{text: text.Text, locator: newValueLocator(files, expr), external: text}
+		for _, literal := range text.Literals {
+			source.addSegment(text.files(), literal.Start, literal.Value, literal.Expr.SrcRange)
+		}
+		return fn(text.Text, source)
+	}
+
+	if template, ok := expr.(*hclsyntax.TemplateExpr); ok && !template.IsStringLiteral() {
+		policy, literals := renderTemplate(runner, files, template)
+		source := newPolicySource(files, expr, policy)
+		for _, literal := range literals {
+			source.addSegment(files, literal.Start, literal.Value, literal.Expr.SrcRange)
+		}
+		return fn(policy, source)
+	}
+
+	err := evaluateString(runner, expr, func(policy string) error {
+		return fn(policy, newPolicySource(files, expr, policy))
+	})
+	if err != nil && !strings.Contains(err.Error(), "cannot convert") {
+		return err
+	}
+	return nil
+}
+
+// policySource maps positions in the evaluated text of a policy back to the
+// configuration. Text written out verbatim, as in a heredoc around its
+// interpolations, maps position by position. A policy encoded from jsonencode()
+// syntax maps each value to the expression that wrote it. Otherwise values are
+// looked up in the literal text of the expression.
+type policySource struct {
+	text     string
+	segments []policySegment
+	locator  *valueLocator
+
+	// leaves are the sources of the string values of a policy encoded from
+	// jsonencode() syntax, by the offset of the value in the text
+	leaves map[int]*policySource
+	// external is the file the policy was read from by file() or templatefile()
+	external *externalText
+}
+
+// policySegment is a part of the policy text written out verbatim at Range
+type policySegment struct {
+	Start int
+	Text  string
+	Range hcl.Range
+}
+
+func newPolicySource(files map[string]*hcl.File, expr hcl.Expression, text string) *policySource {
+	source := &policySource{text: text, locator: newValueLocator(files, expr)}
+
+	var literal *hclsyntax.LiteralValueExpr
+	switch e := expr.(type) {
+	case *hclsyntax.LiteralValueExpr:
+		literal = e
+	case *hclsyntax.TemplateExpr:
+		if len(e.Parts) == 1 {
+			literal, _ = e.Parts[0].(*hclsyntax.LiteralValueExpr)
+		}
+	}
+
+	if literal != nil {
+		source.addSegment(files, 0, text, literal.SrcRange)
+	}
+
+	return source
+}
+
+// addSegment notes that text, found at start in the policy text, is written at
+// rng. Text that differs from its source, as with escapes, is not noted.
+func (s *policySource) addSegment(files map[string]*hcl.File, start int, text string, rng hcl.Range) {
+	if src, ok := sourceText(files, rng); ok && src == text {
+		s.segments = append(s.segments, policySegment{Start: start, Text: text, Range: rng})
+	}
+}
+
+// textRange returns the range of text[start:end], which holds the given value
+func (s *policySource) textRange(kind, value string, start, end int) hcl.Range {
+	for _, segment := range s.segments {
+		if segment.Start <= start && end <= segment.Start+len(segment.Text) {
+			return subRange(segment.Range, segment.Text, start-segment.Start, end-segment.Start)
+		}
+	}
+	return s.locator.next(kind, value)
+}
+
+// emit reports a match found at rng. A match found in a file read by file()
+// or templatefile() is reported on the call, with the line of the file.
+func (s *policySource) emit(runner tflint.Runner, rule tflint.Rule, match Match, rng hcl.Range) error {
+	if s.external == nil {
+		return emitIssue(runner, rule, match, rng)
+	}
+
+	line := 0
+	if rng.Filename == s.external.Path {
+		line = rng.Start.Line
+	}
+	match.Message = s.external.message(match.Message, line)
+	return emitIssue(runner, rule, match, s.external.Call)
+}
+
+// valueRange returns the range of value.Value[start:end]
+func (s *policySource) valueRange(value *awsmeta.PolicyString, kind string, start, end int) hcl.Range {
+	if leaf, exists := s.leaves[value.Start]; exists {
+		return leaf.textRange(kind, value.Value[start:end], start, end)
+	}
+
+	// Only values written without escapes map position by position
+	offset := value.Start
+	if s.text[offset] == '"' {
+		offset++
+	}
+	if offset+len(value.Value) <= len(s.text) && s.text[offset:offset+len(value.Value)] == value.Value {
+		return s.textRange(kind, value.Value[start:end], offset+start, offset+end)
+	}
+	return s.locator.next(kind, value.Value[start:end])
+}
+
+// policyDocumentSchema is the part of an aws_iam_policy_document data source
+// that holds policy values. The sid is left out, as it only labels a statement.
+var policyDocumentSchema = &hclext.BodySchema{
+	Blocks: []hclext.BlockSchema{
+		{
+			Type:       "data",
+			LabelNames: []string{"type", "name"},
+			Body: &hclext.BodySchema{
+				Blocks: []hclext.BlockSchema{
+					{
+						Type: "statement",
+						Body: &hclext.BodySchema{
+							Attributes: []hclext.AttributeSchema{
+								{Name: "actions"},
+								{Name: "not_actions"},
+								{Name: "resources"},
+								{Name: "not_resources"},
+							},
+							Blocks: []hclext.BlockSchema{
+								{Type: "principals", Body: &hclext.BodySchema{Attributes: []hclext.AttributeSchema{{Name: "identifiers"}}}},
+								{Type: "not_principals", Body: &hclext.BodySchema{Attributes: []hclext.AttributeSchema{{Name: "identifiers"}}}},
+								{Type: "condition", Body: &hclext.BodySchema{Attributes: []hclext.AttributeSchema{{Name: "values"}}}},
+							},
+						},
+					},
+				},
+			},
+		},
+	},
+}
+
+// policyDocumentValue is a value of an aws_iam_policy_document data source
+type policyDocumentValue struct {
+	Value string
+
+	// Locator finds the value within the attribute holding it. It is shared
+	// by the values of one attribute, so repeated values each get their own range.
+	Locator *valueLocator
+}
+
+// getPolicyDocumentValues returns the values of every aws_iam_policy_document
+// data source in the module, one slice per data source. Values that are not
+// known yet, such as references to other resources, are skipped, while the
+// known values of the same list are still returned.
+func getPolicyDocumentValues(runner tflint.Runner, files map[string]*hcl.File) ([][]policyDocumentValue, error) {
+	content, err := runner.GetModuleContent(policyDocumentSchema, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var documents [][]policyDocumentValue
+	for _, data := range content.Blocks {
+		if data.Labels[0] != "aws_iam_policy_document" {
+			continue
+		}
+
+		var attributes []*hclext.Attribute
+		for _, statement := range data.Body.Blocks {
+			for _, name := range []string{"actions", "not_actions", "resources", "not_resources"} {
+				if attr, exists := statement.Body.Attributes[name]; exists {
+					attributes = append(attributes, attr)
+				}
+			}
+			for _, block := range statement.Body.Blocks {
+				for _, attr := range block.Body.Attributes {
+					attributes = append(attributes, attr)
+				}
+			}
+		}
+
+		var values []policyDocumentValue
+		for _, attr := range attributes {
+			locator := newValueLocator(files, attr.Expr)
+			err := evaluateValue(runner, attr.Expr, func(list cty.Value) error {
+				if !list.IsKnown() || list.IsNull() || !list.CanIterateElements() {
+					return nil
+				}
+				for it := list.ElementIterator(); it.Next(); {
+					_, value := it.Element()
+					if value.IsKnown() && !value.IsNull() && value.Type() == cty.String {
+						values = append(values, policyDocumentValue{Value: value.AsString(), Locator: locator})
+					}
+				}
+				return nil
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+		documents = append(documents, values)
+	}
+
+	return documents, nil
+}
@@ -0,0 +1,132 @@
+package rules
+
+import (
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+)
+
+// jsonencodeArgument returns the argument of a jsonencode() call
+func jsonencodeArgument(expr hcl.Expression) (hcl.Expression, bool) {
+	call, ok := expr.(*hclsyntax.FunctionCallExpr)
+	if !ok || call.Name != "jsonencode" || len(call.Args) != 1 || call.ExpandFinal {
+		return nil, false
+	}
+	return call.Args[0], true
+}
+
+// jsonLeaf is a string value of an encoded expression
+type jsonLeaf struct {
+	// Start is the offset of the value's opening quote in the encoded text
+	Start int
+	Value string
+	Expr  hcl.Expression
+}
+
+// encodeStaticJSON encodes expr as JSON by walking its object and tuple syntax
+// and evaluating only the leaves. A leaf that is not known yet is encoded as
+// null, so it doesn't hide the rest of the value. Object keys keep the order
+// they are written in. The string leaves are returned with where they were
+// encoded, so values can be traced back to the expression that wrote them.
+func encodeStaticJSON(runner tflint.Runner, expr hcl.Expression) (string, []jsonLeaf, error) {
+	encoder := &staticJSONEncoder{runner: runner}
+	if err := encoder.encode(expr); err != nil {
+		return "", nil, err
+	}
+	return encoder.buf.String(), encoder.leaves, nil
+}
+
+type staticJSONEncoder struct {
+	runner tflint.Runner
+	buf    strings.Builder
+	leaves []jsonLeaf
+}
+
+func (e *staticJSONEncoder) encode(expr hcl.Expression) error {
+	switch expr := expr.(type) {
+	case *hclsyntax.ParenthesesExpr:
+		return e.encode(expr.Expression)
+
+	case *hclsyntax.ObjectConsExpr:
+		e.buf.WriteByte('{')
+		written := 0
+		for _, item := range expr.Items {
+			key, err := staticValue(e.runner, item.KeyExpr)
+			if err != nil {
+				return err
+			}
+			key, err = convert.Convert(key, cty.String)
+			if err != nil || !key.IsKnown() || key.IsNull() || key.IsMarked() {
+				// An item without a known key can't be placed in the object
+				continue
+			}
+
+			if written > 0 {
+				e.buf.WriteByte(',')
+			}
+			written++
+			if err := e.write(key); err != nil {
+				return err
+			}
+			e.buf.WriteByte(':')
+			if err := e.encode(item.ValueExpr); err != nil {
+				return err
+			}
+		}
+		e.buf.WriteByte('}')
+		return nil
+
+	case *hclsyntax.TupleConsExpr:
+		e.buf.WriteByte('[')
+		for i, elem := range expr.Exprs {
+			if i > 0 {
+				e.buf.WriteByte(',')
+			}
+			if err := e.encode(elem); err != nil {
+				return err
+			}
+		}
+		e.buf.WriteByte(']')
+		return nil
+	}
+
+	value, err := staticValue(e.runner, expr)
+	if err != nil {
+		return err
+	}
+	if value.IsKnown() && !value.IsNull() && !value.IsMarked() && value.Type() == cty.String {
+		e.leaves = append(e.leaves, jsonLeaf{Start: e.buf.Len(), Value: value.AsString(), Expr: expr})
+	}
+	return e.write(value)
+}
+
+// write writes value as JSON, or null if it is not wholly known
+func (e *staticJSONEncoder) write(value cty.Value) error {
+	if value.IsNull() || !value.IsWhollyKnown() || value.ContainsMarked() {
+		e.buf.WriteString("null")
+		return nil
+	}
+
+	encoded, err := ctyjson.Marshal(value, value.Type())
+	if err != nil {
+		return err
+	}
+	e.buf.Write(encoded)
+	return nil
+}
+
+// staticValue returns the value of expr. Expressions that need neither
+// variables nor functions, such as literals, are evaluated without the runner.
+func staticValue(runner tflint.Runner, expr hcl.Expression) (cty.Value, error) {
+	value := cty.DynamicVal
+	err := evaluateValue(runner, expr, func(v cty.Value) error {
+		value = v
+		return nil
+	})
+	return value, err
+}
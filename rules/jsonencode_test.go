@@ -0,0 +1,81 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_EncodeStaticJSON(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Content  string
+		Expected string
+	}{
+		{
+			Name:     "literal values keep their order",
+			Content:  `policy = jsonencode({ Version = "2012-10-17", "Statement" = [{ Effect = "Allow", Count = 2, Enabled = true }] })`,
+			Expected: `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Count":2,"Enabled":true}]}`,
+		},
+		{
+			Name:     "unknown values are encoded as null",
+			Content:  "policy = jsonencode({ Resource = [\"arn:aws:s3:::${var.unknown}\", \"arn:aws:s3:::logs\"], Condition = var.unknown })",
+			Expected: `{"Resource":[null,"arn:aws:s3:::logs"],"Condition":null}`,
+		},
+		{
+			Name:     "known variables are evaluated",
+			Content:  `policy = jsonencode({ Resource = var.known, (var.known) = ["a"] })`,
+			Expected: `{"Resource":"arn:aws:s3:::known","arn:aws:s3:::known":["a"]}`,
+		},
+		{
+			Name:     "items with unknown keys are left out",
+			Content:  `policy = jsonencode({ (var.unknown) = "a", Effect = "Deny" })`,
+			Expected: `{"Effect":"Deny"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			content := `
+variable "unknown" {}
+variable "known" {
+  default = "arn:aws:s3:::known"
+}
+` + test.Content
+			runner := helper.TestRunner(t, map[string]string{"main.tf": content})
+
+			file, err := runner.GetFile("main.tf")
+			if err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+			attributes, _ := file.Body.JustAttributes()
+
+			arg, ok := jsonencodeArgument(attributes["policy"].Expr)
+			if !ok {
+				t.Fatal("Expected a jsonencode() call")
+			}
+
+			got, _, err := encodeStaticJSON(runner, arg)
+			if err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+			if got != test.Expected {
+				t.Errorf("Expected %s, got %s", test.Expected, got)
+			}
+		})
+	}
+}
+
+func Test_JsonencodeArgument(t *testing.T) {
+	for _, src := range []string{`"{}"`, `jsondecode("{}")`, `jsonencode(local.a, local.b)`, `jsonencode(local.a...)`} {
+		expr, diags := hclsyntax.ParseExpression([]byte(src), "main.tf", hcl.InitialPos)
+		if diags.HasErrors() {
+			t.Fatalf("Unexpected error occurred: %s", diags)
+		}
+		if _, ok := jsonencodeArgument(expr); ok {
+			t.Errorf("Expected %s not to be taken as a jsonencode() argument", src)
+		}
+	}
+}
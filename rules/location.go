@@ -0,0 +1,336 @@
+package rules
+
+import (
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/myerscode/tflint-ruleset-aws-meta/rules/awsmeta"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// location describes where a range sits in the configuration: the top-level
+// block holding it and the path to it within that block
+type location struct {
+	BlockType string
+	Labels    []string
+
+	// Path holds the nested block types, the attribute name, and any object
+	// keys or tuple indexes leading to the range (e.g. "tags", "Region")
+	Path []string
+}
+
+// ResourceType returns the type of the resource or data source holding the range
+func (l location) ResourceType() string {
+	if (l.BlockType == "resource" || l.BlockType == "data") && len(l.Labels) > 0 {
+		return l.Labels[0]
+	}
+	return ""
+}
+
+// Address returns the address of the block holding the range, such as
+// "aws_instance.web", "data.aws_region.current" or "module.vpc". Within a
+// locals block it is the address of the local value, such as "local.region",
+// and within a variable block the reference to it, such as "var.region".
+func (l location) Address() string {
+	switch l.BlockType {
+	case "resource":
+		return strings.Join(l.Labels, ".")
+	case "locals":
+		if len(l.Path) == 0 {
+			return l.BlockType
+		}
+		return "local." + l.Path[0]
+	case "variable":
+		return strings.Join(append([]string{"var"}, l.Labels...), ".")
+	case "":
+		return ""
+	default:
+		return strings.Join(append([]string{l.BlockType}, l.Labels...), ".")
+	}
+}
+
+// issueAddress returns the address of the block holding an issue at rng, or
+// its file name outside of any block, such as in a file read with file()
+func issueAddress(loc location, rng hcl.Range) string {
+	if address := loc.Address(); address != "" {
+		return address
+	}
+	return filepath.ToSlash(rng.Filename)
+}
+
+// addressRange returns the range of the definition of the block with the
+// given address, or of the local value it names
+func addressRange(files map[string]*hcl.File, address string) (hcl.Range, bool) {
+	for _, file := range files {
+		body, ok := file.Body.(*hclsyntax.Body)
+		if !ok {
+			continue
+		}
+		for _, block := range body.Blocks {
+			if block.Type != "locals" {
+				if (location{BlockType: block.Type, Labels: block.Labels}).Address() == address {
+					return block.DefRange(), true
+				}
+				continue
+			}
+			for name, attr := range block.Body.Attributes {
+				if "local."+name == address {
+					return attr.NameRange, true
+				}
+			}
+		}
+	}
+	return hcl.Range{}, false
+}
+
+// AttributePath returns the dotted path to the range within its block. Within
+// a locals block the path starts after the name of the local value, which is
+// part of the address.
+func (l location) AttributePath() string {
+	if l.BlockType == "locals" && len(l.Path) > 0 {
+		return strings.Join(l.Path[1:], ".")
+	}
+	return strings.Join(l.Path, ".")
+}
+
+// locate finds the location of the given range. Only HCL native syntax files
+// can be located; for JSON syntax files the location is empty.
+func locate(files map[string]*hcl.File, rng hcl.Range) location {
+	var loc location
+
+	file, ok := files[rng.Filename]
+	if !ok {
+		return loc
+	}
+	body, ok := file.Body.(*hclsyntax.Body)
+	if !ok {
+		return loc
+	}
+
+	for _, block := range body.Blocks {
+		if rangeContains(block.Range(), rng) {
+			loc.BlockType = block.Type
+			loc.Labels = block.Labels
+			loc.Path = locateInBody(block.Body, rng)
+			return loc
+		}
+	}
+
+	for _, attr := range body.Attributes {
+		if rangeContains(attr.SrcRange, rng) {
+			loc.Path = append([]string{attr.Name}, locateInExpr(attr.Expr, rng)...)
+			return loc
+		}
+	}
+
+	return loc
+}
+
+func locateInBody(body *hclsyntax.Body, rng hcl.Range) []string {
+	for _, attr := range body.Attributes {
+		if rangeContains(attr.SrcRange, rng) {
+			return append([]string{attr.Name}, locateInExpr(attr.Expr, rng)...)
+		}
+	}
+
+	for _, block := range body.Blocks {
+		if !rangeContains(block.Range(), rng) {
+			continue
+		}
+
+		// Dynamic blocks are located as the block they generate, so that
+		// "dynamic "replica" { content { ... } }" reads as "replica"
+		if block.Type == "dynamic" && len(block.Labels) > 0 {
+			for _, content := range block.Body.Blocks {
+				if content.Type == "content" && rangeContains(content.Range(), rng) {
+					return append([]string{block.Labels[0]}, locateInBody(content.Body, rng)...)
+				}
+			}
+			return append([]string{block.Labels[0]}, locateInBody(block.Body, rng)...)
+		}
+
+		return append([]string{block.Type}, locateInBody(block.Body, rng)...)
+	}
+
+	return nil
+}
+
+func locateInExpr(expr hclsyntax.Expression, rng hcl.Range) []string {
+	switch e := expr.(type) {
+	case *hclsyntax.ObjectConsExpr:
+		for _, item := range e.Items {
+			if rangeContains(item.ValueExpr.Range(), rng) {
+				return append([]string{objectKey(item.KeyExpr)}, locateInExpr(item.ValueExpr, rng)...)
+			}
+		}
+	case *hclsyntax.TupleConsExpr:
+		for i, elem := range e.Exprs {
+			if rangeContains(elem.Range(), rng) {
+				return append([]string{strconv.Itoa(i)}, locateInExpr(elem, rng)...)
+			}
+		}
+	case *hclsyntax.FunctionCallExpr:
+		for _, arg := range e.Args {
+			if rangeContains(arg.Range(), rng) {
+				return locateInExpr(arg, rng)
+			}
+		}
+	case *hclsyntax.ParenthesesExpr:
+		return locateInExpr(e.Expression, rng)
+	}
+
+	return nil
+}
+
+// objectKey returns the name of an object key, or its source-like form when
+// the key is not a plain name or string
+func objectKey(expr hclsyntax.Expression) string {
+	if key, ok := expr.(*hclsyntax.ObjectConsKeyExpr); ok {
+		if name := hcl.ExprAsKeyword(key.Wrapped); name != "" {
+			return name
+		}
+		expr = key.Wrapped
+	}
+
+	if val, diags := expr.Value(nil); !diags.HasErrors() && val.IsKnown() && !val.IsNull() && val.Type() == cty.String {
+		return val.AsString()
+	}
+
+	return "*"
+}
+
+// subRange returns the range of src[start:end], where src is the source text
+// of rng
+func subRange(rng hcl.Range, src string, start, end int) hcl.Range {
+	return hcl.Range{
+		Filename: rng.Filename,
+		Start:    advancePos(rng.Start, src[:start]),
+		End:      advancePos(rng.Start, src[:end]),
+	}
+}
+
+// advancePos returns the position reached by moving over text from pos
+func advancePos(pos hcl.Pos, text string) hcl.Pos {
+	for _, line := range strings.SplitAfter(text, "\n") {
+		if strings.HasSuffix(line, "\n") {
+			pos.Line++
+			pos.Column = 1
+		} else {
+			pos.Column += utf8.RuneCountInString(line)
+		}
+		pos.Byte += len(line)
+	}
+	return pos
+}
+
+// valueRanges returns the ranges where a hardcoded value of the given kind is
+// written in the literal text of expr, in source order. Interpolations are not
+// searched, as a value they produce has no text of its own. Partitions are
+// only looked for in the partition field of ARNs, as "aws" is common text.
+// It returns nil when the value cannot be found.
+func valueRanges(files map[string]*hcl.File, expr hcl.Expression, kind, value string) []hcl.Range {
+	return literalRanges(files, expr, kind, value, isValueByte)
+}
+
+// fragmentRanges is valueRanges for values found in a fragment of a template,
+// such as the region of "${var.name}-us-east-1", which only need to stand
+// apart from letters and digits
+func fragmentRanges(files map[string]*hcl.File, expr hcl.Expression, kind, value string) []hcl.Range {
+	return literalRanges(files, expr, kind, value, isAlphanumericByte)
+}
+
+// literalRanges returns the ranges of value in the literal text of expr that
+// are not part of a longer word made of wordByte
+func literalRanges(files map[string]*hcl.File, expr hcl.Expression, kind, value string, wordByte func(byte) bool) []hcl.Range {
+	if value == "" {
+		return nil
+	}
+
+	// The literal parts of native syntax expressions, or else the whole
+	// expression, such as a string in JSON syntax
+	var literals []hcl.Range
+	if native, ok := expr.(hclsyntax.Expression); ok {
+		hclsyntax.VisitAll(native, func(node hclsyntax.Node) hcl.Diagnostics {
+			if lit, ok := node.(*hclsyntax.LiteralValueExpr); ok {
+				literals = append(literals, lit.SrcRange)
+			}
+			return nil
+		})
+	} else {
+		literals = []hcl.Range{expr.Range()}
+	}
+
+	var ranges []hcl.Range
+	for _, rng := range literals {
+		src, ok := sourceText(files, rng)
+		if !ok {
+			continue
+		}
+
+		if kind == kindPartition {
+			for _, arn := range awsmeta.FindARNFields(src) {
+				if arn.Partition != nil && src[arn.Partition.Start:arn.Partition.End] == value {
+					ranges = append(ranges, subRange(rng, src, arn.Partition.Start, arn.Partition.End))
+				}
+			}
+			continue
+		}
+
+		for offset := 0; ; {
+			idx := strings.Index(src[offset:], value)
+			if idx < 0 {
+				break
+			}
+			start, end := offset+idx, offset+idx+len(value)
+			offset = end
+
+			// Skip values that are part of a longer word, such as the region
+			// of an availability zone
+			if (start > 0 && wordByte(src[start-1])) || (end < len(src) && wordByte(src[end])) {
+				continue
+			}
+			ranges = append(ranges, subRange(rng, src, start, end))
+		}
+	}
+
+	return ranges
+}
+
+func isValueByte(c byte) bool {
+	return c == '_' || c == '-' || isAlphanumericByte(c)
+}
+
+func isAlphanumericByte(c byte) bool {
+	return ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9')
+}
+
+// valueLocator hands out the ranges of values within an expression. Each call
+// for the same value returns its next occurrence, so repeated values are
+// reported where each one is written.
+type valueLocator struct {
+	files map[string]*hcl.File
+	expr  hcl.Expression
+	seen  map[string]int
+}
+
+func newValueLocator(files map[string]*hcl.File, expr hcl.Expression) *valueLocator {
+	return &valueLocator{files: files, expr: expr, seen: make(map[string]int)}
+}
+
+// next returns the range of the next occurrence of the value, or the range of
+// the whole expression once no occurrence is left
+func (l *valueLocator) next(kind, value string) hcl.Range {
+	key := kind + ":" + value
+	ranges := valueRanges(l.files, l.expr, kind, value)
+
+	i := l.seen[key]
+	l.seen[key]++
+	if i < len(ranges) {
+		return ranges[i]
+	}
+	return l.expr.Range()
+}
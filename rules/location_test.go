@@ -0,0 +1,160 @@
+package rules
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+func Test_Locate(t *testing.T) {
+	src := `
+resource "aws_iam_role" "test" {
+  assume_role_policy = jsonencode({
+    Statement = [{
+      Principal = {
+        Service = "s3.amazonaws.com"
+      }
+    }]
+  })
+}
+
+data "aws_iam_policy_document" "test" {
+  statement {
+    resources = ["arn:aws:s3:::bucket"]
+  }
+}
+
+module "vpc" {
+  azs = ["eu-west-1a"]
+}
+
+locals {
+  regions = {
+    primary = "us-east-1"
+  }
+  partition = "aws-cn"
+}
+
+variable "zone" {
+  default = "eu-west-2b"
+}`
+
+	file, diags := hclparse.NewParser().ParseHCL([]byte(src), "main.tf")
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+	files := map[string]*hcl.File{"main.tf": file}
+
+	tests := []struct {
+		Needle       string
+		Address      string
+		ResourceType string
+		Path         string
+	}{
+		{Needle: "s3.amazonaws.com", Address: "aws_iam_role.test", ResourceType: "aws_iam_role", Path: "assume_role_policy.Statement.0.Principal.Service"},
+		{Needle: "arn:aws:s3:::bucket", Address: "data.aws_iam_policy_document.test", ResourceType: "aws_iam_policy_document", Path: "statement.resources.0"},
+		{Needle: "eu-west-1a", Address: "module.vpc", ResourceType: "", Path: "azs.0"},
+		{Needle: "us-east-1", Address: "local.regions", ResourceType: "", Path: "primary"},
+		{Needle: "aws-cn", Address: "local.partition", ResourceType: "", Path: ""},
+		{Needle: "eu-west-2b", Address: "var.zone", ResourceType: "", Path: "default"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Needle, func(t *testing.T) {
+			start := strings.Index(src, test.Needle)
+			rng := hcl.Range{
+				Filename: "main.tf",
+				Start:    hcl.Pos{Byte: start},
+				End:      hcl.Pos{Byte: start + len(test.Needle)},
+			}
+
+			loc := locate(files, rng)
+			if loc.Address() != test.Address {
+				t.Errorf("Expected address %q, got %q", test.Address, loc.Address())
+			}
+			if loc.ResourceType() != test.ResourceType {
+				t.Errorf("Expected resource type %q, got %q", test.ResourceType, loc.ResourceType())
+			}
+			if loc.AttributePath() != test.Path {
+				t.Errorf("Expected path %q, got %q", test.Path, loc.AttributePath())
+			}
+		})
+	}
+}
+
+func Test_ValueRanges(t *testing.T) {
+	src := `
+resource "aws_iam_policy" "test" {
+  policy = <<EOF
+{
+  "Resource": [
+    "arn:aws:sqs:eu-west-1:123456789012:queue",
+    "arn:aws:sns:${var.region}:123456789012:topic"
+  ],
+  "Condition": {"StringEquals": {"aws:SourceAccount": "123456789012"}}
+}
+EOF
+}
+
+resource "aws_instance" "test" {
+  availability_zone = "eu-west-1a"
+}`
+
+	file, diags := hclparse.NewParser().ParseHCL([]byte(src), "main.tf")
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+	files := map[string]*hcl.File{"main.tf": file}
+
+	body := file.Body.(*hclsyntax.Body)
+	policy := body.Blocks[0].Body.Attributes["policy"].Expr
+	az := body.Blocks[1].Body.Attributes["availability_zone"].Expr
+
+	// offsets returns the byte offsets of every occurrence of needle
+	offsets := func(needle string) []int {
+		var result []int
+		for i := 0; ; {
+			idx := strings.Index(src[i:], needle)
+			if idx < 0 {
+				return result
+			}
+			result = append(result, i+idx)
+			i += idx + len(needle)
+		}
+	}
+
+	tests := []struct {
+		Name     string
+		Expr     hcl.Expression
+		Kind     string
+		Value    string
+		Expected []int
+	}{
+		{Name: "region", Expr: policy, Kind: kindRegion, Value: "eu-west-1", Expected: offsets("eu-west-1")[:1]},
+		{Name: "partitions in ARNs only", Expr: policy, Kind: kindPartition, Value: "aws", Expected: []int{strings.Index(src, "aws:sqs"), strings.Index(src, "aws:sns")}},
+		{Name: "account IDs", Expr: policy, Kind: kindAccountID, Value: "123456789012", Expected: offsets("123456789012")},
+		{Name: "region of an availability zone", Expr: az, Kind: kindRegion, Value: "eu-west-1", Expected: nil},
+		{Name: "availability zone", Expr: az, Kind: kindAvailabilityZone, Value: "eu-west-1a", Expected: offsets("eu-west-1a")},
+		{Name: "interpolation", Expr: policy, Kind: kindRegion, Value: "us-east-1", Expected: nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			ranges := valueRanges(files, test.Expr, test.Kind, test.Value)
+			if len(ranges) != len(test.Expected) {
+				t.Fatalf("Expected %d ranges, got %d: %v", len(test.Expected), len(ranges), ranges)
+			}
+			for i, rng := range ranges {
+				if rng.Start.Byte != test.Expected[i] || rng.End.Byte != test.Expected[i]+len(test.Value) {
+					t.Errorf("Expected range %d at byte %d, got %s", i, test.Expected[i], rng)
+				}
+				if got := src[rng.Start.Byte:rng.End.Byte]; got != test.Value {
+					t.Errorf("Expected range %d to cover %q, got %q", i, test.Value, got)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package rules
+
+import (
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// policyAttribute is a resource attribute that holds an IAM policy document
+type policyAttribute struct {
+	ResourceType string
+
+	// Block is the nested block holding the attribute, if any
+	Block string
+
+	Attribute string
+}
+
+// policyAttributes lists the resource attributes that hold IAM policy
+// documents. Supporting another policy-bearing resource only takes an entry here.
+//
+// aws_lambda_layer_version_permission is not listed: its policy is computed
+// from the action and principal arguments rather than written out.
+var policyAttributes = []policyAttribute{
+	{ResourceType: "aws_iam_policy", Attribute: "policy"},
+	{ResourceType: "aws_iam_role_policy", Attribute: "policy"},
+	{ResourceType: "aws_iam_role", Attribute: "assume_role_policy"},
+	{ResourceType: "aws_iam_role", Block: "inline_policy", Attribute: "policy"},
+	{ResourceType: "aws_iam_user_policy", Attribute: "policy"},
+	{ResourceType: "aws_iam_group_policy", Attribute: "policy"},
+	{ResourceType: "aws_s3_bucket_policy", Attribute: "policy"},
+	{ResourceType: "aws_sqs_queue_policy", Attribute: "policy"},
+	{ResourceType: "aws_sns_topic_policy", Attribute: "policy"},
+	{ResourceType: "aws_kms_key", Attribute: "policy"},
+	{ResourceType: "aws_ecr_repository_policy", Attribute: "policy"},
+	{ResourceType: "aws_secretsmanager_secret_policy", Attribute: "policy"},
+	{ResourceType: "aws_organizations_policy", Attribute: "content"},
+}
+
+// getPolicyAttributes returns the attributes holding IAM policy documents in
+// the module, for the resource types accepted by include
+func getPolicyAttributes(runner tflint.Runner, include func(resourceType string) bool) ([]*hclext.Attribute, error) {
+	// Group the registry by resource type, so each type is fetched once
+	var resourceTypes []string
+	schemas := make(map[string]*hclext.BodySchema)
+	for _, pa := range policyAttributes {
+		if !include(pa.ResourceType) {
+			continue
+		}
+
+		schema, exists := schemas[pa.ResourceType]
+		if !exists {
+			schema = &hclext.BodySchema{}
+			schemas[pa.ResourceType] = schema
+			resourceTypes = append(resourceTypes, pa.ResourceType)
+		}
+
+		if pa.Block == "" {
+			schema.Attributes = append(schema.Attributes, hclext.AttributeSchema{Name: pa.Attribute})
+		} else {
+			schema.Blocks = append(schema.Blocks, hclext.BlockSchema{
+				Type: pa.Block,
+				Body: &hclext.BodySchema{Attributes: []hclext.AttributeSchema{{Name: pa.Attribute}}},
+			})
+		}
+	}
+
+	var attributes []*hclext.Attribute
+	for _, resourceType := range resourceTypes {
+		resources, err := runner.GetResourceContent(resourceType, schemas[resourceType], nil)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, resource := range resources.Blocks {
+			for _, pa := range policyAttributes {
+				if pa.ResourceType != resourceType {
+					continue
+				}
+
+				if pa.Block == "" {
+					if attr, exists := resource.Body.Attributes[pa.Attribute]; exists {
+						attributes = append(attributes, attr)
+					}
+					continue
+				}
+
+				for _, block := range resource.Body.Blocks {
+					if attr, exists := block.Body.Attributes[pa.Attribute]; exists && block.Type == pa.Block {
+						attributes = append(attributes, attr)
+					}
+				}
+			}
+		}
+	}
+
+	return attributes, nil
+}
+
+// isRolePolicy reports whether the resource type is aws_iam_role_policy, which
+// has rules of its own. The aws_iam_policy rules cover every other policy-bearing resource.
+func isRolePolicy(resourceType string) bool {
+	return resourceType == "aws_iam_role_policy"
+}
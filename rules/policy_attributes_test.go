@@ -0,0 +1,75 @@
+package rules
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_GetPolicyAttributes(t *testing.T) {
+	content := `
+resource "aws_iam_role" "test" {
+  assume_role_policy = "{}"
+
+  inline_policy {
+    name   = "inline"
+    policy = "{}"
+  }
+}
+
+resource "aws_iam_role_policy" "test" {
+  policy = "{}"
+}
+
+resource "aws_s3_bucket_policy" "test" {
+  policy = "{}"
+}
+
+resource "aws_organizations_policy" "test" {
+  content = "{}"
+}
+
+resource "aws_s3_bucket" "test" {
+  policy = "{}"
+}`
+
+	tests := []struct {
+		Name     string
+		Include  func(resourceType string) bool
+		Expected []string
+	}{
+		{
+			Name:     "every policy-bearing resource",
+			Include:  func(string) bool { return true },
+			Expected: []string{"assume_role_policy", "content", "policy", "policy", "policy"},
+		},
+		{
+			Name:     "role policies only",
+			Include:  isRolePolicy,
+			Expected: []string{"policy"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			runner := helper.TestRunner(t, map[string]string{"main.tf": content})
+
+			attributes, err := getPolicyAttributes(runner, test.Include)
+			if err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			var names []string
+			for _, attr := range attributes {
+				names = append(names, attr.Name)
+			}
+			sort.Strings(names)
+
+			if strings.Join(names, ",") != strings.Join(test.Expected, ",") {
+				t.Errorf("Expected attributes %v, got %v", test.Expected, names)
+			}
+		})
+	}
+}
@@ -0,0 +1,221 @@
+package rules
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"slices"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/myerscode/tflint-ruleset-aws-meta/rules/awsmeta"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// RuleSet is the aws-meta ruleset. It accepts the plugin configuration and
+// wraps the runner of every check so the enabled rules can share work, such
+// as the expression scan, and honour the plugin-wide exclusions.
+type RuleSet struct {
+	tflint.BuiltinRuleSet
+
+	config     *Config
+	exclusions *exclusions
+	baseline   *baseline
+	fix        bool
+}
+
+// ApplyGlobalConfig applies the common config to the ruleset
+func (r *RuleSet) ApplyGlobalConfig(config *tflint.Config) error {
+	r.fix = config.Fix
+	return r.BuiltinRuleSet.ApplyGlobalConfig(config)
+}
+
+// ConfigSchema returns the schema of the plugin block
+func (r *RuleSet) ConfigSchema() *hclext.BodySchema {
+	return hclext.ImpliedBodySchema(&Config{})
+}
+
+// ApplyConfig applies the plugin block configuration
+func (r *RuleSet) ApplyConfig(body *hclext.BodyContent) error {
+	r.config = &Config{}
+	if diags := hclext.DecodeBody(body, nil, r.config); diags.HasErrors() {
+		return diags
+	}
+
+	exclusions, err := newExclusions(r.config)
+	if err != nil {
+		return err
+	}
+	r.exclusions = exclusions
+
+	if r.config.CatalogFile != "" {
+		overrides, err := awsmeta.LoadCatalogOverrides(r.config.CatalogFile)
+		if err != nil {
+			return fmt.Errorf("failed to load catalog_file: %w", err)
+		}
+
+		catalog := awsmeta.MergeCatalog(awsmeta.DefaultCatalog(), overrides)
+		for _, rule := range r.Rules {
+			if cr, ok := rule.(catalogRule); ok {
+				cr.useCatalog(catalog)
+			}
+		}
+	}
+
+	if r.config.UpdateBaseline && r.config.BaselineFile == "" {
+		return errors.New("update_baseline requires baseline_file")
+	}
+	if r.config.UpdateBaseline && !slices.ContainsFunc(r.EnabledRules, func(rule tflint.Rule) bool {
+		return rule.Name() == baselineRuleName
+	}) {
+		return fmt.Errorf("update_baseline requires the %s rule, which writes the baseline", baselineRuleName)
+	}
+	if r.config.BaselineFile != "" {
+		known, err := loadBaseline(r.config.BaselineFile)
+		switch {
+		case errors.Is(err, fs.ErrNotExist) && r.config.UpdateBaseline:
+			known = &baseline{Filename: r.config.BaselineFile}
+		case err != nil:
+			return fmt.Errorf("failed to load baseline_file: %w", err)
+		}
+		r.baseline = known
+	}
+
+	return nil
+}
+
+// NewRunner returns a runner that carries the state shared by the rules
+// during a single check
+func (r *RuleSet) NewRunner(runner tflint.Runner) (tflint.Runner, error) {
+	var walkerRules []walkerRule
+	enabled := make(map[string]bool)
+	for _, rule := range r.EnabledRules {
+		if wr, ok := rule.(walkerRule); ok {
+			walkerRules = append(walkerRules, wr)
+		}
+		enabled[rule.Name()] = true
+	}
+
+	exclusions := r.exclusions
+	if exclusions == nil {
+		exclusions, _ = newExclusions(nil)
+	}
+
+	var baseline *baselineCheck
+	if r.baseline != nil {
+		module, err := runner.GetModulePath()
+		if err != nil {
+			return nil, err
+		}
+		baseline = newBaselineCheck(r.baseline, module.String(), r.config.UpdateBaseline, enabled)
+	}
+
+	return &checkRunner{
+		Runner:     runner,
+		scanner:    newExpressionScanner(walkerRules),
+		exclusions: exclusions,
+		configs:    make(map[string]*ruleConfig),
+		baseline:   baseline,
+		fix:        r.fix,
+	}, nil
+}
+
+// checkRunner wraps the host runner for the duration of one check
+type checkRunner struct {
+	tflint.Runner
+	scanner    *expressionScanner
+	exclusions *exclusions
+	fix        bool
+	files      map[string]*hcl.File
+
+	// configs are the decoded rule configurations by rule name
+	configs map[string]*ruleConfig
+
+	// baseline matches the findings of the check against the baseline file,
+	// if there is one
+	baseline *baselineCheck
+
+	// annots are the aws-meta:allow annotations of the module, which track
+	// their use across the rules of the check
+	annots []*annotation
+	parsed bool
+}
+
+// EmitIssue sends the issue to TFLint unless its location is excluded
+func (r *checkRunner) EmitIssue(rule tflint.Rule, message string, issueRange hcl.Range) error {
+	excluded, err := r.excluded(issueRange)
+	if err != nil || excluded {
+		return err
+	}
+	return r.Runner.EmitIssue(rule, message, issueRange)
+}
+
+// EmitIssueWithFix sends the issue and its fix to TFLint unless its location is excluded
+func (r *checkRunner) EmitIssueWithFix(rule tflint.Rule, message string, issueRange hcl.Range, fixFunc func(f tflint.Fixer) error) error {
+	excluded, err := r.excluded(issueRange)
+	if err != nil || excluded {
+		return err
+	}
+
+	// TFLint applies the fixes of a rule once the rule has finished, which
+	// moves the source of every rule after it. Anything worked out from the
+	// old source has to be worked out again.
+	if r.fix {
+		r.scanner.reset()
+		r.files = nil
+	}
+
+	return r.Runner.EmitIssueWithFix(rule, message, issueRange, fixFunc)
+}
+
+// annotations returns the aws-meta:allow annotations of the module. They are
+// parsed once per check, so their use is kept as fixes change the source.
+func (r *checkRunner) annotations() ([]*annotation, error) {
+	if !r.parsed {
+		files, err := r.Runner.GetFiles()
+		if err != nil {
+			return nil, err
+		}
+		r.annots, r.parsed = parseAnnotations(files), true
+	}
+	return r.annots, nil
+}
+
+// runnerFiles returns the files of the module, which are shared by the rules
+// within a check of the ruleset
+func runnerFiles(runner tflint.Runner) (map[string]*hcl.File, error) {
+	if r, ok := runner.(*checkRunner); ok {
+		return r.moduleFiles()
+	}
+	return runner.GetFiles()
+}
+
+// excluded reports whether the plugin configuration excludes the given range
+func (r *checkRunner) excluded(rng hcl.Range) (bool, error) {
+	if r.exclusions.excludesPath(rng.Filename) {
+		return true, nil
+	}
+
+	if !r.exclusions.needsLocation() {
+		return false, nil
+	}
+
+	files, err := r.moduleFiles()
+	if err != nil {
+		return false, err
+	}
+	return r.exclusions.excludesLocation(locate(files, rng)), nil
+}
+
+// moduleFiles returns the files of the module, which are fetched once until
+// a fix changes them
+func (r *checkRunner) moduleFiles() (map[string]*hcl.File, error) {
+	if r.files == nil {
+		files, err := r.Runner.GetFiles()
+		if err != nil {
+			return nil, err
+		}
+		r.files = files
+	}
+	return r.files, nil
+}
@@ -0,0 +1,225 @@
+package rules
+
+import (
+	"os"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// applyPluginConfig applies the body of a "plugin" block to the ruleset
+func applyPluginConfig(t *testing.T, ruleset *RuleSet, src string) {
+	t.Helper()
+
+	file, diags := hclsyntax.ParseConfig([]byte(src), "plugin.hcl", hcl.InitialPos)
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+	content, diags := hclext.Content(file.Body, ruleset.ConfigSchema())
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+	if err := ruleset.ApplyConfig(content); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+}
+
+func Test_RuleSetExclusions(t *testing.T) {
+	files := map[string]string{
+		"main.tf": `
+resource "aws_instance" "test" {
+  availability_zone = "us-east-1a"
+  tags = {
+    Region = "us-east-1"
+  }
+}
+
+resource "aws_dynamodb_table" "test" {
+  replica {
+    region_name = "eu-west-1"
+  }
+  dynamic "replica" {
+    for_each = []
+    content {
+      region_name = "eu-west-2"
+    }
+  }
+}
+
+module "vpc" {
+  source = "./modules/vpc"
+  azs    = ["eu-west-1a"]
+}`,
+		"generated/providers.tf": `
+resource "aws_lambda_permission" "test" {
+  source_arn = "arn:aws:s3:eu-west-1:123456789012:bucket/my-bucket"
+}`,
+	}
+
+	tests := []struct {
+		Name          string
+		Config        string
+		ExpectedCount int
+	}{
+		{
+			Name:          "no exclusions",
+			Config:        ``,
+			ExpectedCount: 7,
+		},
+		{
+			Name:          "excluded paths",
+			Config:        `exclude_paths = ["generated/**"]`,
+			ExpectedCount: 5,
+		},
+		{
+			Name:          "excluded resource types",
+			Config:        `exclude_resource_types = ["aws_instance"]`,
+			ExpectedCount: 5,
+		},
+		{
+			Name:          "excluded attribute wildcard",
+			Config:        `exclude_attributes = ["tags.*"]`,
+			ExpectedCount: 6,
+		},
+		{
+			Name:          "excluded attribute of a resource type, including dynamic blocks",
+			Config:        `exclude_attributes = ["aws_dynamodb_table.replica.region_name"]`,
+			ExpectedCount: 5,
+		},
+		{
+			Name:          "excluded modules",
+			Config:        `exclude_modules = ["vpc"]`,
+			ExpectedCount: 6,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			ruleset := &RuleSet{BuiltinRuleSet: tflint.BuiltinRuleSet{
+				Rules: []tflint.Rule{NewAwsMetaHardcodedRule()},
+			}}
+			if err := ruleset.ApplyGlobalConfig(&tflint.Config{}); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+			applyPluginConfig(t, ruleset, test.Config)
+
+			testRunner := helper.TestRunner(t, files)
+			runner, err := ruleset.NewRunner(testRunner)
+			if err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+			for _, rule := range ruleset.EnabledRules {
+				if err := rule.Check(runner); err != nil {
+					t.Fatalf("Unexpected error occurred: %s", err)
+				}
+			}
+
+			if len(testRunner.Issues) != test.ExpectedCount {
+				t.Errorf("Expected %d issues, got %d", test.ExpectedCount, len(testRunner.Issues))
+				for i, issue := range testRunner.Issues {
+					t.Logf("Issue %d: %s", i+1, issue.Message)
+				}
+			}
+		})
+	}
+}
+
+func Test_RuleSetInvalidConfig(t *testing.T) {
+	ruleset := &RuleSet{}
+
+	file, _ := hclsyntax.ParseConfig([]byte(`exclude_attributes = ["tags.[a"]`), "plugin.hcl", hcl.InitialPos)
+	content, diags := hclext.Content(file.Body, ruleset.ConfigSchema())
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+
+	if err := ruleset.ApplyConfig(content); err == nil {
+		t.Fatal("Expected an error for an invalid pattern, got none")
+	}
+}
+
+func Test_RuleSetCatalogFile(t *testing.T) {
+	t.Chdir(t.TempDir())
+	overrides := `
+regions = ["example-east-1"]
+
+partition "aws-example" {
+  dns_suffix = "amazonaws.example"
+}`
+	if err := os.WriteFile("catalog.hcl", []byte(overrides), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	files := map[string]string{
+		"main.tf": `
+resource "aws_lambda_permission" "test" {
+  principal  = "events.amazonaws.example"
+  source_arn = "arn:aws-example:events:example-east-1:123456789012:rule/test"
+}`,
+	}
+
+	tests := []struct {
+		Name          string
+		Config        string
+		ExpectedCount int
+	}{
+		{
+			Name:          "embedded catalog",
+			Config:        ``,
+			ExpectedCount: 0,
+		},
+		{
+			Name:          "catalog file",
+			Config:        `catalog_file = "catalog.hcl"`,
+			ExpectedCount: 3,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			ruleset := &RuleSet{BuiltinRuleSet: tflint.BuiltinRuleSet{
+				Rules: []tflint.Rule{NewAwsMetaHardcodedRule(), NewAwsServicePrincipalHardcodedRule()},
+			}}
+			if err := ruleset.ApplyGlobalConfig(&tflint.Config{}); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+			applyPluginConfig(t, ruleset, test.Config)
+
+			testRunner := helper.TestRunner(t, files)
+			runner, err := ruleset.NewRunner(testRunner)
+			if err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+			for _, rule := range ruleset.EnabledRules {
+				if err := rule.Check(runner); err != nil {
+					t.Fatalf("Unexpected error occurred: %s", err)
+				}
+			}
+
+			if len(testRunner.Issues) != test.ExpectedCount {
+				t.Errorf("Expected %d issues, got %d", test.ExpectedCount, len(testRunner.Issues))
+				for i, issue := range testRunner.Issues {
+					t.Logf("Issue %d: %s", i+1, issue.Message)
+				}
+			}
+		})
+	}
+}
+
+func Test_RuleSetMissingCatalogFile(t *testing.T) {
+	ruleset := &RuleSet{}
+
+	file, _ := hclsyntax.ParseConfig([]byte(`catalog_file = "missing.hcl"`), "plugin.hcl", hcl.InitialPos)
+	content, diags := hclext.Content(file.Body, ruleset.ConfigSchema())
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+
+	if err := ruleset.ApplyConfig(content); err == nil {
+		t.Fatal("Expected an error for a missing catalog file, got none")
+	}
+}
@@ -0,0 +1,504 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/myerscode/tflint-ruleset-aws-meta/rules/awsmeta"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// Kinds of hardcoded values reported by the detectors
+const (
+	kindRegion           = "region"
+	kindPartition        = "partition"
+	kindAvailabilityZone = "availability_zone"
+	kindAccountID        = "account_id"
+	kindAMIID            = "ami_id"
+	kindServicePrincipal = "service_principal"
+	kindDNSSuffix        = "dns_suffix"
+
+	// kindPrincipalSuffix is the DNS suffix of a service principal whose
+	// service name is not known, as in "${var.service}.amazonaws.com"
+	kindPrincipalSuffix = "principal_suffix"
+)
+
+// findingKinds are the kinds of hardcoded values, which annotations and
+// severities are set for
+var findingKinds = []string{
+	kindRegion,
+	kindPartition,
+	kindAvailabilityZone,
+	kindAccountID,
+	kindAMIID,
+	kindServicePrincipal,
+	kindDNSSuffix,
+	kindPrincipalSuffix,
+}
+
+// Match is a hardcoded value found by a detector
+type Match struct {
+	Kind    string
+	Value   string
+	Message string
+
+	// Replacement is the expression suggested instead of the value, when it
+	// is more specific than the one of its kind
+	Replacement string
+
+	// Region is the region of an availability zone, as the matcher reads it
+	// off the start of the zone, so a Local Zone such as "us-west-2-lax-1a"
+	// has one too
+	Region string
+}
+
+// Detector holds the matching logic for one family of hardcoded values.
+// The scanner takes care of walking, de-duplication and evaluation, so a
+// detector only decides what counts as a match.
+type Detector interface {
+	// Candidate reports whether the expression may contain a match, based on
+	// its raw source text. It is called before evaluation and must be cheap.
+	Candidate(expr hcl.Expression, src string) bool
+
+	// Detect returns the matches found in the evaluated string value.
+	Detect(value string) []Match
+}
+
+// unevaluatedDetector is implemented by detectors that can still report
+// matches from the raw source text when an expression fails to evaluate.
+type unevaluatedDetector interface {
+	DetectUnevaluated(src string) []Match
+}
+
+// templateDetector is implemented by detectors that can still report matches
+// from the literal text of a template whose value is not known yet, such as
+// "${var.name}-us-east-1", or from the skeleton of a string built by format(),
+// join() or replace(). The text has templatePlaceholder in place of the
+// unknown parts.
+type templateDetector interface {
+	DetectTemplate(text string) []Match
+}
+
+// walkerRule is a rule whose findings come from the expression scanner
+type walkerRule interface {
+	tflint.Rule
+
+	// detectors returns the detectors of the rule, or an error if they can't
+	// be set up, such as when the AWS catalog fails to load
+	detectors() ([]Detector, error)
+}
+
+// fixableRule is a walker rule that can fix its findings with --fix
+type fixableRule interface {
+	walkerRule
+
+	// fixes returns a fix for each of the findings that will be reported,
+	// or nil for findings it cannot fix
+	fixes(findings []finding, ctx *fixContext) []func(tflint.Fixer) error
+}
+
+type finding struct {
+	Match
+
+	// Range is the range of the expression holding the match
+	Range hcl.Range
+
+	// Source is the raw source text of Range
+	Source string
+
+	// ValueRange is the range of the value itself within Range, which is
+	// where the issue is reported
+	ValueRange hcl.Range
+
+	// References are the locals and variables the value may come from, when
+	// it is not written in the expression itself
+	References []string
+}
+
+// expressionScanner walks every expression of a module once, pre-filters it
+// on its source text, evaluates the remaining candidates once and hands the
+// value to the detectors of every registered rule.
+type expressionScanner struct {
+	rules       []walkerRule
+	scanned     bool
+	files       map[string]*hcl.File
+	definitions map[string]*definition
+	detectors   map[string][]Detector
+	findings    map[string][]finding
+}
+
+func newExpressionScanner(rules []walkerRule) *expressionScanner {
+	return &expressionScanner{rules: rules}
+}
+
+// scanExpressions reports the findings of a walker rule. When the rule runs as
+// part of the ruleset the scan is shared with the other enabled walker rules,
+// otherwise the rule is scanned on its own.
+func scanExpressions(runner tflint.Runner, rule walkerRule) error {
+	scanner := newExpressionScanner([]walkerRule{rule})
+	if r, ok := runner.(*checkRunner); ok {
+		scanner = r.scanner
+	}
+
+	if err := scanner.scan(runner); err != nil {
+		return err
+	}
+
+	config, err := decodeRuleConfig(runner, rule)
+	if err != nil {
+		return err
+	}
+
+	var findings []finding
+	for _, f := range scanner.findings[rule.Name()] {
+		if !config.allowsMatch(f.Match) {
+			findings = append(findings, f)
+		}
+	}
+
+	var fixes []func(tflint.Fixer) error
+	if fr, ok := rule.(fixableRule); ok {
+		accounts, err := decodeRuleConfig(runner, NewAwsHardcodedIDsRule())
+		if err != nil {
+			return err
+		}
+		fixes = fr.fixes(findings, newFixContext(scanner.files, config, accounts))
+	}
+
+	for i, f := range findings {
+		if i < len(fixes) && fixes[i] != nil {
+			if err := emitIssueWithFix(runner, rule, f.Match, f.ValueRange, fixes[i]); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := emitIssue(runner, rule, f.Match, f.ValueRange); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// reset discards the findings so the next rule scans the module again
+func (s *expressionScanner) reset() {
+	s.scanned = false
+	s.files = nil
+	s.definitions = nil
+	s.detectors = nil
+	s.findings = nil
+}
+
+func (s *expressionScanner) scan(runner tflint.Runner) error {
+	if s.scanned {
+		return nil
+	}
+	s.scanned = true
+	s.findings = make(map[string][]finding)
+
+	s.detectors = make(map[string][]Detector)
+	for _, rule := range s.rules {
+		detectors, err := rule.detectors()
+		if err != nil {
+			return err
+		}
+		s.detectors[rule.Name()] = detectors
+	}
+
+	// Get all source files upfront so we can inspect raw expression text
+	// before making expensive gRPC EvaluateExpr calls
+	files, err := runner.GetFiles()
+	if err != nil {
+		return err
+	}
+	s.files = files
+	s.definitions = moduleDefinitions(files)
+
+	// Files excluded by the plugin configuration are not worth evaluating
+	var excluded func(filename string) bool
+	if r, ok := runner.(*checkRunner); ok {
+		excluded = r.exclusions.excludesPath
+	}
+
+	// Track which expressions we've already checked to avoid duplicates
+	checked := make(map[string]bool)
+
+	// The verbs of a format string are not text, so format strings are only
+	// looked at through the skeleton of their format() call, which is walked
+	// first
+	var formatSpecs []hcl.Range
+
+	diags := runner.WalkExpressions(tflint.ExprWalkFunc(func(expr hcl.Expression) hcl.Diagnostics {
+		exprRange := expr.Range()
+		exprKey := fmt.Sprintf("%s:%d:%d", exprRange.Filename, exprRange.Start.Line, exprRange.Start.Column)
+		if checked[exprKey] {
+			return nil
+		}
+		checked[exprKey] = true
+
+		if excluded != nil && excluded(exprRange.Filename) {
+			return nil
+		}
+
+		if call, ok := expr.(*hclsyntax.FunctionCallExpr); ok && call.Name == "format" && len(call.Args) > 0 {
+			formatSpecs = append(formatSpecs, call.Args[0].Range())
+		}
+		for _, spec := range formatSpecs {
+			if rangeContains(spec, exprRange) {
+				return nil
+			}
+		}
+
+		src, ok := sourceText(files, exprRange)
+		if !ok {
+			return nil
+		}
+
+		if text, ok := readExternalText(expr); ok {
+			s.scanExternalText(expr, src, text)
+			return nil
+		}
+
+		candidates := s.candidates(expr, src)
+
+		// The pieces of a string built by a function call may only look
+		// like an ARN once joined, so such calls are rendered up front.
+		// Rendering evaluates the arguments, so other calls are left alone.
+		var skeleton string
+		var rendered bool
+		if isSkeletonCall(expr) && (len(candidates) > 0 || buildsARN(expr)) {
+			if text, ok := renderSkeleton(runner, files, expr); ok {
+				skeleton, rendered = text, true
+				if len(candidates) == 0 {
+					candidates = s.candidates(expr, skeleton)
+				}
+			}
+		}
+		if len(candidates) == 0 {
+			return nil
+		}
+
+		// Literals are evaluated locally, anything else is a gRPC call to TFLint
+		var value string
+		var evaluated bool
+		err := evaluateString(runner, expr, func(v string) error {
+			value = v
+			evaluated = true
+			return nil
+		})
+
+		// A template that isn't known as a whole may still hardcode values
+		// in its literal text
+		if tmpl, ok := expr.(*hclsyntax.TemplateExpr); ok && !evaluated && !tmpl.IsStringLiteral() {
+			if text, ok := renderSkeleton(runner, files, tmpl); ok {
+				skeleton, rendered = text, true
+			}
+		}
+
+		for _, rule := range s.rules {
+			locator := newValueLocator(files, expr)
+			for _, detector := range candidates[rule.Name()] {
+				var matches, fragments []Match
+				switch {
+				case evaluated:
+					matches = detector.Detect(value)
+				case err != nil:
+					if d, ok := detector.(unevaluatedDetector); ok {
+						matches = d.DetectUnevaluated(src)
+					}
+				}
+				if d, ok := detector.(templateDetector); ok && rendered && !evaluated {
+					fragments = d.DetectTemplate(skeleton)
+				}
+
+				for _, match := range matches {
+					s.findings[rule.Name()] = append(s.findings[rule.Name()], finding{
+						Match:      match,
+						Range:      exprRange,
+						Source:     src,
+						ValueRange: findingRange(locator, expr, match),
+						References: s.references(expr, match),
+					})
+				}
+				for _, match := range fragments {
+					valueRange := findingRange(locator, expr, match)
+					if ranges := fragmentRanges(files, expr, match.Kind, match.Value); valueRange == exprRange && len(ranges) > 0 {
+						valueRange = ranges[0]
+					}
+					s.findings[rule.Name()] = append(s.findings[rule.Name()], finding{
+						Match:      match,
+						Range:      exprRange,
+						Source:     src,
+						ValueRange: valueRange,
+					})
+				}
+			}
+		}
+
+		return nil
+	}))
+
+	if diags.HasErrors() {
+		return diags
+	}
+
+	for name, findings := range s.findings {
+		s.findings[name] = traceFindings(innermostFindings(findings), s.definitions)
+	}
+
+	return nil
+}
+
+// references returns the locals and variables a match may come from, unless
+// its value is written in the expression itself
+func (s *expressionScanner) references(expr hcl.Expression, match Match) []string {
+	refs := definitionReferences(s.definitions, expr)
+	if len(refs) == 0 || len(valueRanges(s.files, expr, match.Kind, match.Value)) > 0 {
+		return nil
+	}
+	return refs
+}
+
+// candidates returns the detectors of each rule that may find a match in text
+func (s *expressionScanner) candidates(expr hcl.Expression, text string) map[string][]Detector {
+	candidates := make(map[string][]Detector)
+	for _, rule := range s.rules {
+		for _, detector := range s.detectors[rule.Name()] {
+			if detector.Candidate(expr, text) {
+				candidates[rule.Name()] = append(candidates[rule.Name()], detector)
+			}
+		}
+	}
+	return candidates
+}
+
+// scanExternalText hands the text of a file read by file() or templatefile()
+// to the detectors. The string values of a JSON file, such as a policy or
+// container definitions, are looked at one by one, and any other file, such
+// as a user data script, line by line. Matches are reported on the call, with
+// the line of the file they were found on.
+func (s *expressionScanner) scanExternalText(expr hcl.Expression, src string, text *externalText) {
+	values, err := awsmeta.JSONStrings(text.Text)
+	if err != nil {
+		s.scanExternalLines(expr, src, text)
+		return
+	}
+
+	for _, value := range values {
+		for _, rule := range s.rules {
+			for _, detector := range s.detectors[rule.Name()] {
+				if !detector.Candidate(expr, value.Value) {
+					continue
+				}
+				for _, match := range detector.Detect(value.Value) {
+					s.addExternalFinding(rule, match, src, text, value.Start)
+				}
+			}
+		}
+	}
+}
+
+// scanExternalLines hands the lines of a plain text file to the detectors.
+// A line is free text, like the literal text of a template, so detectors that
+// look for values standing apart in a template do so in the line too.
+func (s *expressionScanner) scanExternalLines(expr hcl.Expression, src string, text *externalText) {
+	offset := 0
+	for _, line := range strings.SplitAfter(text.Text, "\n") {
+		start := offset
+		offset += len(line)
+		line = strings.TrimRight(line, "\r\n")
+
+		for _, rule := range s.rules {
+			for _, detector := range s.detectors[rule.Name()] {
+				if !detector.Candidate(expr, line) {
+					continue
+				}
+				var matches []Match
+				if d, ok := detector.(templateDetector); ok {
+					matches = d.DetectTemplate(line)
+				} else {
+					matches = detector.Detect(line)
+				}
+				for _, match := range matches {
+					s.addExternalFinding(rule, match, src, text, start)
+				}
+			}
+		}
+	}
+}
+
+// addExternalFinding records a match found in the text of a file at offset.
+// Source is the call rather than the file, so fixes, which rewrite Source,
+// leave these findings alone.
+func (s *expressionScanner) addExternalFinding(rule walkerRule, match Match, src string, text *externalText, offset int) {
+	match.Message = text.message(match.Message, text.line(offset))
+	s.findings[rule.Name()] = append(s.findings[rule.Name()], finding{
+		Match:      match,
+		Range:      text.Call,
+		Source:     src,
+		ValueRange: text.Call,
+	})
+}
+
+// innermostFindings drops findings that are also reported by an expression
+// nested inside them. WalkExpressions visits nested expressions too, so
+// without this a single hardcoded value would be reported once by the
+// literal holding it and again by every expression wrapping that literal.
+func innermostFindings(findings []finding) []finding {
+	type findingKey struct{ kind, value string }
+	byKey := make(map[findingKey][]hcl.Range)
+	for _, f := range findings {
+		key := findingKey{f.Kind, f.Value}
+		byKey[key] = append(byKey[key], f.Range)
+	}
+
+	var result []finding
+	for _, f := range findings {
+		nested := false
+		for _, other := range byKey[findingKey{f.Kind, f.Value}] {
+			if other != f.Range && rangeContains(f.Range, other) {
+				nested = true
+				break
+			}
+		}
+		if !nested {
+			result = append(result, f)
+		}
+	}
+	return result
+}
+
+// findingRange returns the range to report a match at: where its value is
+// written within the expression, or the whole expression when the value is
+// not written out, such as a dns_suffix principal built from a template. A
+// value found more than once is reported where each one is written, in turn.
+func findingRange(locator *valueLocator, expr hcl.Expression, match Match) hcl.Range {
+	if match.Kind == kindDNSSuffix {
+		return expr.Range()
+	}
+	return locator.next(match.Kind, match.Value)
+}
+
+// rangeContains reports whether inner lies within outer
+func rangeContains(outer, inner hcl.Range) bool {
+	return outer.Filename == inner.Filename &&
+		outer.Start.Byte <= inner.Start.Byte &&
+		inner.End.Byte <= outer.End.Byte
+}
+
+// sourceText returns the raw source text of the given range
+func sourceText(files map[string]*hcl.File, rng hcl.Range) (string, bool) {
+	file, ok := files[rng.Filename]
+	if !ok {
+		return "", false
+	}
+
+	src := file.Bytes
+	if rng.Start.Byte >= len(src) || rng.End.Byte > len(src) {
+		return "", false
+	}
+
+	return string(src[rng.Start.Byte:rng.End.Byte]), true
+}
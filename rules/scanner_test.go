@@ -0,0 +1,368 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// countingRunner counts the expression walks and evaluations made through it
+type countingRunner struct {
+	*helper.Runner
+	walks       int
+	evaluations int
+}
+
+func (r *countingRunner) WalkExpressions(walker tflint.ExprWalker) hcl.Diagnostics {
+	r.walks++
+	return r.Runner.WalkExpressions(walker)
+}
+
+func (r *countingRunner) EvaluateExpr(expr hcl.Expression, target interface{}, option *tflint.EvaluateExprOption) error {
+	r.evaluations++
+	return r.Runner.EvaluateExpr(expr, target, option)
+}
+
+func Test_ExpressionScannerSharedAcrossRules(t *testing.T) {
+	content := `
+variable "region" {
+  default = "eu-west-1"
+}
+
+resource "aws_lambda_permission" "test" {
+  source_arn = "arn:aws:s3:${var.region}:123456789012:bucket/my-bucket"
+}
+
+resource "aws_iam_role" "test" {
+  assume_role_policy = jsonencode({
+    Statement = [{
+      Principal = {
+        Service = "s3.amazonaws.com"
+      }
+    }]
+  })
+}`
+
+	walkerRules := []tflint.Rule{
+		NewAwsMetaHardcodedRule(),
+		NewAwsHardcodedIDsRule(),
+		NewAwsServicePrincipalHardcodedRule(),
+		NewAwsServicePrincipalDNSSuffixRule(),
+	}
+
+	// Issues reported when each rule scans on its own
+	standalone := &countingRunner{Runner: helper.TestRunner(t, map[string]string{"main.tf": content})}
+	for _, rule := range walkerRules {
+		if err := rule.Check(standalone); err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+	}
+
+	ruleset := &RuleSet{BuiltinRuleSet: tflint.BuiltinRuleSet{Rules: walkerRules}}
+	if err := ruleset.ApplyGlobalConfig(&tflint.Config{Rules: map[string]*tflint.RuleConfig{
+		"aws_hardcoded_ids": {Name: "aws_hardcoded_ids", Enabled: true},
+	}}); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	shared := &countingRunner{Runner: helper.TestRunner(t, map[string]string{"main.tf": content})}
+	runner, err := ruleset.NewRunner(shared)
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	for _, rule := range ruleset.EnabledRules {
+		if err := rule.Check(runner); err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+	}
+
+	if shared.walks != 1 {
+		t.Errorf("Expected 1 walk, got %d", shared.walks)
+	}
+	if shared.evaluations >= standalone.evaluations {
+		t.Errorf("Expected fewer than %d evaluations, got %d", standalone.evaluations, shared.evaluations)
+	}
+	helper.AssertIssues(t, standalone.Issues, shared.Issues)
+}
+
+func Test_ExpressionScannerReportsInnermostExpression(t *testing.T) {
+	content := `
+resource "aws_instance" "test" {
+  availability_zone = "us-east-1a"
+}`
+
+	rule := NewAwsMetaHardcodedRule()
+	runner := helper.TestRunner(t, map[string]string{"main.tf": content})
+
+	if err := rule.Check(runner); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	helper.AssertIssues(t, helper.Issues{
+		{
+			Rule:    rule,
+			Message: "[AWSMETA003 kind=availability_zone value=us-east-1a address=aws_instance.test replacement=data.aws_availability_zones.available.names] Hardcoded AWS availability zone 'us-east-1a' found. Consider using data.aws_availability_zones to dynamically fetch AZs for the current region",
+			Range: hcl.Range{
+				Filename: "main.tf",
+				Start:    hcl.Pos{Line: 3, Column: 24},
+				End:      hcl.Pos{Line: 3, Column: 34},
+			},
+		},
+	}, runner.Issues)
+}
+
+func Test_ExpressionScannerReportsValueRange(t *testing.T) {
+	content := `
+resource "aws_lambda_permission" "test" {
+  source_arn = "arn:aws:s3:eu-west-1:123456789012:bucket/my-bucket"
+}`
+
+	rule := NewAwsMetaHardcodedRule()
+	runner := helper.TestRunner(t, map[string]string{"main.tf": content})
+
+	if err := rule.Check(runner); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	helper.AssertIssues(t, helper.Issues{
+		{
+			Rule:    rule,
+			Message: "[AWSMETA001 kind=region value=eu-west-1 address=aws_lambda_permission.test replacement=data.aws_region.current.name] Hardcoded AWS region 'eu-west-1' found in ARN. Consider using data.aws_region.current.name",
+			Range: hcl.Range{
+				Filename: "main.tf",
+				Start:    hcl.Pos{Line: 3, Column: 28},
+				End:      hcl.Pos{Line: 3, Column: 37},
+			},
+		},
+		{
+			Rule:    rule,
+			Message: "[AWSMETA002 kind=partition value=aws address=aws_lambda_permission.test replacement=data.aws_partition.current.partition] Hardcoded AWS partition 'aws' found in ARN. Consider using data.aws_partition.current.partition",
+			Range: hcl.Range{
+				Filename: "main.tf",
+				Start:    hcl.Pos{Line: 3, Column: 21},
+				End:      hcl.Pos{Line: 3, Column: 24},
+			},
+		},
+	}, runner.Issues)
+}
+
+func Test_ExpressionScannerEvaluatesLiteralsLocally(t *testing.T) {
+	content := `
+resource "aws_instance" "test" {
+  availability_zone = "us-east-1a"
+  tags = {
+    Queue = "arn:aws:sqs:eu-west-1:${"123456789012"}:jobs"
+  }
+}`
+
+	rule := NewAwsMetaHardcodedRule()
+	runner := &countingRunner{Runner: helper.TestRunner(t, map[string]string{"main.tf": content})}
+
+	if err := rule.Check(runner); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	if len(runner.Issues) != 3 {
+		t.Errorf("Expected 3 issues, got %d", len(runner.Issues))
+	}
+	if runner.evaluations != 0 {
+		t.Errorf("Expected no evaluations, got %d", runner.evaluations)
+	}
+}
+
+func Test_ExpressionScannerReportsTemplateFragments(t *testing.T) {
+	content := `
+variable "name" {}
+variable "partition" {}
+variable "service" {}
+
+resource "aws_sqs_queue" "test" {
+  name = "${var.name}-us-east-1"
+  tags = {
+    Queue   = "arn:${var.partition}:sqs:eu-west-1:${var.name}:jobs"
+    Service = "${var.service}.amazonaws.com"
+  }
+}`
+
+	metaRule := NewAwsMetaHardcodedRule()
+	principalRule := NewAwsServicePrincipalHardcodedRule()
+	runner := helper.TestRunner(t, map[string]string{"main.tf": content})
+
+	for _, rule := range []tflint.Rule{metaRule, principalRule} {
+		if err := rule.Check(runner); err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+	}
+
+	helper.AssertIssues(t, helper.Issues{
+		{
+			Rule:    metaRule,
+			Message: "[AWSMETA001 kind=region value=us-east-1 address=aws_sqs_queue.test replacement=data.aws_region.current.name] Hardcoded AWS region 'us-east-1' found. Consider using data.aws_region.current.name",
+			Range: hcl.Range{
+				Filename: "main.tf",
+				Start:    hcl.Pos{Line: 7, Column: 23},
+				End:      hcl.Pos{Line: 7, Column: 32},
+			},
+		},
+		{
+			Rule:    metaRule,
+			Message: "[AWSMETA001 kind=region value=eu-west-1 address=aws_sqs_queue.test replacement=data.aws_region.current.name] Hardcoded AWS region 'eu-west-1' found in ARN. Consider using data.aws_region.current.name",
+			Range: hcl.Range{
+				Filename: "main.tf",
+				Start:    hcl.Pos{Line: 9, Column: 41},
+				End:      hcl.Pos{Line: 9, Column: 50},
+			},
+		},
+		{
+			Rule:    principalRule,
+			Message: "[AWSMETA008 kind=principal_suffix value=amazonaws.com address=aws_sqs_queue.test replacement=data.aws_service_principal] Hardcoded DNS suffix 'amazonaws.com' found in service principal. Consider using data.aws_service_principal for multi-partition compatibility",
+			Range: hcl.Range{
+				Filename: "main.tf",
+				Start:    hcl.Pos{Line: 10, Column: 31},
+				End:      hcl.Pos{Line: 10, Column: 44},
+			},
+		},
+	}, runner.Issues)
+}
+
+func Test_ExpressionScannerReportsFunctionSkeletons(t *testing.T) {
+	content := `
+variable "account" {}
+variable "bucket" {}
+variable "service" {}
+
+resource "aws_iam_role" "test" {
+  name = format("arn:aws:iam::%s:role/app", var.account)
+  tags = {
+    Bucket  = join(":", ["arn", "aws", "s3", "", "", var.bucket])
+    Service = format("%s.amazonaws.com", var.service)
+  }
+}`
+
+	metaRule := NewAwsMetaHardcodedRule()
+	principalRule := NewAwsServicePrincipalHardcodedRule()
+	runner := helper.TestRunner(t, map[string]string{"main.tf": content})
+
+	for _, rule := range []tflint.Rule{metaRule, principalRule} {
+		if err := rule.Check(runner); err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+	}
+
+	helper.AssertIssues(t, helper.Issues{
+		{
+			Rule:    metaRule,
+			Message: "[AWSMETA002 kind=partition value=aws address=aws_iam_role.test replacement=data.aws_partition.current.partition] Hardcoded AWS partition 'aws' found in ARN. Consider using data.aws_partition.current.partition",
+			Range: hcl.Range{
+				Filename: "main.tf",
+				Start:    hcl.Pos{Line: 7, Column: 22},
+				End:      hcl.Pos{Line: 7, Column: 25},
+			},
+		},
+		{
+			Rule:    metaRule,
+			Message: "[AWSMETA002 kind=partition value=aws address=aws_iam_role.test replacement=data.aws_partition.current.partition] Hardcoded AWS partition 'aws' found in ARN. Consider using data.aws_partition.current.partition",
+			Range: hcl.Range{
+				Filename: "main.tf",
+				Start:    hcl.Pos{Line: 9, Column: 15},
+				End:      hcl.Pos{Line: 9, Column: 66},
+			},
+		},
+		{
+			Rule:    principalRule,
+			Message: "[AWSMETA008 kind=principal_suffix value=amazonaws.com address=aws_iam_role.test replacement=data.aws_service_principal] Hardcoded DNS suffix 'amazonaws.com' found in service principal. Consider using data.aws_service_principal for multi-partition compatibility",
+			Range: hcl.Range{
+				Filename: "main.tf",
+				Start:    hcl.Pos{Line: 10, Column: 26},
+				End:      hcl.Pos{Line: 10, Column: 39},
+			},
+		},
+	}, runner.Issues)
+}
+
+func Test_ExpressionScannerReportsSkeletonsWithUnresolvedReferences(t *testing.T) {
+	content := `
+resource "aws_s3_bucket" "logs" {
+  bucket = "logs"
+}
+
+resource "aws_iam_role" "test" {
+  for_each = toset(["app"])
+
+  name = join(":", ["arn", "aws", "s3", "", "", each.key])
+  tags = {
+    Queue = join(":", ["arn", "aws-cn", "sqs", "cn-north-1", aws_s3_bucket.logs.id, "queue"])
+  }
+}`
+
+	rule := NewAwsMetaHardcodedRule()
+	runner := helper.TestRunner(t, map[string]string{"main.tf": content})
+
+	if err := rule.Check(runner); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	helper.AssertIssues(t, helper.Issues{
+		{
+			Rule:    rule,
+			Message: "[AWSMETA002 kind=partition value=aws address=aws_iam_role.test replacement=data.aws_partition.current.partition] Hardcoded AWS partition 'aws' found in ARN. Consider using data.aws_partition.current.partition",
+			Range: hcl.Range{
+				Filename: "main.tf",
+				Start:    hcl.Pos{Line: 9, Column: 10},
+				End:      hcl.Pos{Line: 9, Column: 59},
+			},
+		},
+		{
+			Rule:    rule,
+			Message: "[AWSMETA002 kind=partition value=aws-cn address=aws_iam_role.test replacement=data.aws_partition.current.partition] Hardcoded AWS partition 'aws-cn' found in ARN. Consider using data.aws_partition.current.partition",
+			Range: hcl.Range{
+				Filename: "main.tf",
+				Start:    hcl.Pos{Line: 11, Column: 13},
+				End:      hcl.Pos{Line: 11, Column: 94},
+			},
+		},
+		{
+			Rule:    rule,
+			Message: "[AWSMETA001 kind=region value=cn-north-1 address=aws_iam_role.test replacement=data.aws_region.current.name] Hardcoded AWS region 'cn-north-1' found. Consider using data.aws_region.current.name",
+			Range: hcl.Range{
+				Filename: "main.tf",
+				Start:    hcl.Pos{Line: 11, Column: 49},
+				End:      hcl.Pos{Line: 11, Column: 59},
+			},
+		},
+	}, runner.Issues)
+}
+
+func Test_ExpressionScannerSkipsUnrelatedFunctionSkeletons(t *testing.T) {
+	content := `
+variable "env" {
+  default = "prod"
+}
+variable "names" {
+  default = ["a", "b"]
+}
+
+resource "aws_instance" "test" {
+  tags = {
+    Name  = format("%s-%s", var.env, "web")
+    Names = join(",", var.names)
+    Slug  = replace(var.env, "_", "-")
+  }
+}`
+
+	rule := NewAwsMetaHardcodedRule()
+	runner := &countingRunner{Runner: helper.TestRunner(t, map[string]string{"main.tf": content})}
+
+	if err := rule.Check(runner); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	if len(runner.Issues) != 0 {
+		t.Errorf("Expected no issues, got %d", len(runner.Issues))
+	}
+	if runner.evaluations != 0 {
+		t.Errorf("Expected no evaluations, got %d", runner.evaluations)
+	}
+}
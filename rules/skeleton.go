@@ -0,0 +1,243 @@
+package rules
+
+import (
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// skeletonFunctions are the string-building functions whose calls are
+// rendered by renderSkeleton
+var skeletonFunctions = map[string]bool{
+	"format":  true,
+	"join":    true,
+	"replace": true,
+}
+
+// isSkeletonCall reports whether expr is a call renderSkeleton can render
+func isSkeletonCall(expr hcl.Expression) bool {
+	call, ok := expr.(*hclsyntax.FunctionCallExpr)
+	return ok && skeletonFunctions[call.Name] && !call.ExpandFinal
+}
+
+// buildsARN reports whether the literal parts of a call hold the start of an
+// ARN. An ARN is the one value whose pieces, such as the "arn" and "aws"
+// elements of a join(), don't look like anything on their own, so a call
+// whose source holds no candidate is only rendered when it may build one.
+func buildsARN(expr hcl.Expression) bool {
+	node, ok := expr.(hclsyntax.Node)
+	if !ok {
+		return false
+	}
+	found := false
+	hclsyntax.VisitAll(node, func(node hclsyntax.Node) hcl.Diagnostics {
+		if lit, ok := node.(*hclsyntax.LiteralValueExpr); ok && lit.Val.Type() == cty.String && lit.Val.IsKnown() && !lit.Val.IsNull() {
+			found = found || strings.Contains(lit.Val.AsString(), "arn")
+		}
+		return nil
+	})
+	return found
+}
+
+// renderSkeleton renders the static skeleton of a string built by a
+// template or by format(), join() or replace(), such as "arn:aws:iam::${}:role/${}"
+// for format("arn:aws:iam::%s:role/%s", var.account, var.role). Values that are
+// not known yet are rendered as templatePlaceholder, like the interpolations
+// of renderTemplate. It returns false when the skeleton can't be rendered,
+// such as for a format string that isn't known.
+func renderSkeleton(runner tflint.Runner, files map[string]*hcl.File, expr hcl.Expression) (string, bool) {
+	r := &skeletonRenderer{runner: runner, files: files}
+	var buf strings.Builder
+	if !r.render(&buf, expr) {
+		return "", false
+	}
+	return buf.String(), true
+}
+
+type skeletonRenderer struct {
+	runner tflint.Runner
+	files  map[string]*hcl.File
+}
+
+// render writes the skeleton of expr. Expressions it doesn't look into are
+// evaluated, and written as templatePlaceholder when they are not known or
+// fail to evaluate, such as references the runner can't resolve.
+func (r *skeletonRenderer) render(buf *strings.Builder, expr hcl.Expression) bool {
+	switch expr := expr.(type) {
+	case *hclsyntax.ParenthesesExpr:
+		return r.render(buf, expr.Expression)
+
+	case *hclsyntax.TemplateWrapExpr:
+		return r.render(buf, expr.Wrapped)
+
+	case *hclsyntax.TemplateExpr:
+		if expr.IsStringLiteral() {
+			break
+		}
+		text, _ := renderTemplate(r.runner, r.files, expr)
+		buf.WriteString(text)
+		return true
+
+	case *hclsyntax.FunctionCallExpr:
+		if expr.ExpandFinal {
+			break
+		}
+		switch expr.Name {
+		case "format":
+			return r.format(buf, expr.Args)
+		case "join":
+			return r.join(buf, expr.Args)
+		case "replace":
+			return r.replace(buf, expr.Args)
+		}
+	}
+
+	value, err := staticValue(r.runner, expr)
+	if str, ok := templateString(value); ok && err == nil {
+		buf.WriteString(str)
+	} else {
+		buf.WriteString(templatePlaceholder)
+	}
+	return true
+}
+
+// format renders format(spec, args...). The verbs of spec are replaced with
+// the skeletons of their arguments, except for verbs that change how a known
+// value is written, such as %q or %05d, which are rendered as placeholders.
+func (r *skeletonRenderer) format(buf *strings.Builder, args []hclsyntax.Expression) bool {
+	if len(args) == 0 {
+		return false
+	}
+	spec, ok := r.knownString(args[0])
+	if !ok {
+		return false
+	}
+	args = args[1:]
+
+	for i := 0; i < len(spec); i++ {
+		if spec[i] != '%' {
+			buf.WriteByte(spec[i])
+			continue
+		}
+
+		// Flags, width and precision run up to the verb
+		j := i + 1
+		for j < len(spec) && strings.IndexByte("+-# 0123456789.", spec[j]) >= 0 {
+			j++
+		}
+		if j == len(spec) || spec[j] == '[' {
+			// Explicit argument indexes aren't followed
+			return false
+		}
+		verb := spec[i+1 : j+1]
+		i = j
+
+		if verb == "%" {
+			buf.WriteByte('%')
+			continue
+		}
+		if len(args) == 0 {
+			return false
+		}
+		arg := args[0]
+		args = args[1:]
+
+		switch verb {
+		case "s", "v", "d":
+			if !r.render(buf, arg) {
+				return false
+			}
+		default:
+			buf.WriteString(templatePlaceholder)
+		}
+	}
+	return true
+}
+
+// join renders join(separator, lists...). Lists written as tuples, or
+// concatenated from them with concat(), are rendered element by element, and
+// any other list as a single placeholder.
+func (r *skeletonRenderer) join(buf *strings.Builder, args []hclsyntax.Expression) bool {
+	if len(args) < 2 {
+		return false
+	}
+	separator, ok := r.knownString(args[0])
+	if !ok {
+		return false
+	}
+
+	var elems []hclsyntax.Expression
+	for _, list := range args[1:] {
+		elems = appendListElems(elems, list)
+	}
+	for i, elem := range elems {
+		if i > 0 {
+			buf.WriteString(separator)
+		}
+		if !r.render(buf, elem) {
+			return false
+		}
+	}
+	return true
+}
+
+// appendListElems appends the elements of a list expression to elems. A list
+// that isn't written out is appended as a whole.
+func appendListElems(elems []hclsyntax.Expression, list hclsyntax.Expression) []hclsyntax.Expression {
+	switch list := list.(type) {
+	case *hclsyntax.ParenthesesExpr:
+		return appendListElems(elems, list.Expression)
+	case *hclsyntax.TupleConsExpr:
+		return append(elems, list.Exprs...)
+	case *hclsyntax.FunctionCallExpr:
+		if list.Name == "concat" && !list.ExpandFinal {
+			for _, arg := range list.Args {
+				elems = appendListElems(elems, arg)
+			}
+			return elems
+		}
+	}
+	return append(elems, list)
+}
+
+// replace renders replace(str, substring, replacement) when the substring and
+// replacement are known. Regular expression substrings, written in slashes,
+// aren't rendered.
+func (r *skeletonRenderer) replace(buf *strings.Builder, args []hclsyntax.Expression) bool {
+	if len(args) != 3 {
+		return false
+	}
+	substring, ok := r.knownString(args[1])
+	if !ok {
+		return false
+	}
+	if len(substring) > 1 && strings.HasPrefix(substring, "/") && strings.HasSuffix(substring, "/") {
+		return false
+	}
+	replacement, ok := r.knownString(args[2])
+	if !ok {
+		return false
+	}
+
+	var str strings.Builder
+	if !r.render(&str, args[0]) {
+		return false
+	}
+	buf.WriteString(strings.ReplaceAll(str.String(), substring, replacement))
+	return true
+}
+
+// knownString returns the string value of expr, if it is known
+func (r *skeletonRenderer) knownString(expr hcl.Expression) (string, bool) {
+	value, err := staticValue(r.runner, expr)
+	if err != nil {
+		return "", false
+	}
+	if value.IsKnown() && !value.IsNull() && !value.IsMarked() && value.Type() == cty.String {
+		return value.AsString(), true
+	}
+	return "", false
+}
@@ -0,0 +1,105 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_RenderSkeleton(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Expr     string
+		Expected string
+		Rendered bool
+	}{
+		{
+			Name:     "format",
+			Expr:     `format("arn:aws:iam::%s:role/%s", var.unknown, var.known)`,
+			Expected: "arn:aws:iam::${}:role/aws",
+			Rendered: true,
+		},
+		{
+			Name:     "format with escapes and other verbs",
+			Expr:     `format("%d%%-%q-%05d", var.unknown, var.known, 7)`,
+			Expected: "${}%-${}-${}",
+			Rendered: true,
+		},
+		{
+			Name:     "format with argument indexes",
+			Expr:     `format("%[1]s", var.known)`,
+			Rendered: false,
+		},
+		{
+			Name:     "format with an unknown format string",
+			Expr:     `format(var.unknown, "aws")`,
+			Rendered: false,
+		},
+		{
+			Name:     "join",
+			Expr:     `join(":", ["arn", var.known, "s3", "", "", var.unknown])`,
+			Expected: "arn:aws:s3:::${}",
+			Rendered: true,
+		},
+		{
+			Name:     "references that can't be resolved",
+			Expr:     `join(":", ["arn", "aws", "sqs", "eu-west-1", aws_s3_bucket.logs.id, each.key])`,
+			Expected: "arn:aws:sqs:eu-west-1:${}:${}",
+			Rendered: true,
+		},
+		{
+			Name:     "join with concat and other lists",
+			Expr:     `join(":", concat(["arn", "aws"], var.unknown), ["eu-west-1"])`,
+			Expected: "arn:aws:${}:eu-west-1",
+			Rendered: true,
+		},
+		{
+			Name:     "replace",
+			Expr:     `replace("arn:PARTITION:s3:::${var.unknown}", "PARTITION", "aws")`,
+			Expected: "arn:aws:s3:::${}",
+			Rendered: true,
+		},
+		{
+			Name:     "replace with a regular expression",
+			Expr:     `replace("arn:aws:s3:::bucket", "/aws/", "aws-cn")`,
+			Rendered: false,
+		},
+		{
+			Name:     "nested calls",
+			Expr:     `format("%s.amazonaws.com", join("-", [var.unknown, "events"]))`,
+			Expected: "${}-events.amazonaws.com",
+			Rendered: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			content := `
+variable "unknown" {}
+variable "known" {
+  default = "aws"
+}
+
+locals {
+  value = ` + test.Expr + `
+}`
+			runner := helper.TestRunner(t, map[string]string{"main.tf": content})
+
+			files, err := runner.GetFiles()
+			if err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+			blocks := files["main.tf"].Body.(*hclsyntax.Body).Blocks
+			expr := blocks[len(blocks)-1].Body.Attributes["value"].Expr
+
+			got, rendered := renderSkeleton(runner, files, expr)
+			if rendered != test.Rendered {
+				t.Fatalf("Expected rendered to be %v, got %v", test.Rendered, rendered)
+			}
+			if got != test.Expected {
+				t.Errorf("Expected %q, got %q", test.Expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package rules
+
+import (
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+)
+
+// templatePlaceholder stands in the rendered text of a template for an
+// interpolation whose value is not known yet
+const templatePlaceholder = "${}"
+
+// templateLiteral is a literal part of a rendered template
+type templateLiteral struct {
+	// Start is the offset of the part in the rendered text
+	Start int
+	Value string
+	Expr  *hclsyntax.LiteralValueExpr
+}
+
+// renderTemplate renders a template part by part. Interpolations that are not
+// known yet or fail to evaluate, such as references the runner can't resolve,
+// are rendered as templatePlaceholder, so the literal text around them is kept. Directives such as %{ if } are only rendered when they need
+// no variables, as they can't be evaluated apart from their template. Without
+// a runner, as for a template read by templatefile(), the same goes for every part.
+func renderTemplate(runner tflint.Runner, files map[string]*hcl.File, expr *hclsyntax.TemplateExpr) (string, []templateLiteral) {
+	var buf strings.Builder
+	var literals []templateLiteral
+	for _, part := range expr.Parts {
+		if literal, ok := part.(*hclsyntax.LiteralValueExpr); ok && literal.Val.Type() == cty.String {
+			literals = append(literals, templateLiteral{Start: buf.Len(), Value: literal.Val.AsString(), Expr: literal})
+			buf.WriteString(literal.Val.AsString())
+			continue
+		}
+
+		value := cty.DynamicVal
+		if src, ok := sourceText(files, part.Range()); runner != nil && ok && !strings.HasPrefix(src, "%{") {
+			if v, err := staticValue(runner, part); err == nil {
+				value = v
+			}
+		} else if v, diags := part.Value(nil); !diags.HasErrors() {
+			value = v
+		}
+
+		if str, ok := templateString(value); ok {
+			buf.WriteString(str)
+		} else {
+			buf.WriteString(templatePlaceholder)
+		}
+	}
+
+	return buf.String(), literals
+}
+
+// templateString returns the text an interpolated value renders as, if it is known
+func templateString(value cty.Value) (string, bool) {
+	if value.IsNull() || !value.IsKnown() || value.IsMarked() {
+		return "", false
+	}
+	str, err := convert.Convert(value, cty.String)
+	if err != nil {
+		return "", false
+	}
+	return str.AsString(), true
+}
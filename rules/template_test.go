@@ -0,0 +1,81 @@
+package rules
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_RenderTemplate(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Template string
+		Expected string
+		Literals []string
+	}{
+		{
+			Name:     "known interpolations are rendered",
+			Template: `"arn:${var.known}:s3:::bucket"`,
+			Expected: "arn:aws:s3:::bucket",
+			Literals: []string{"arn:", ":s3:::bucket"},
+		},
+		{
+			Name:     "unknown interpolations are placeholders",
+			Template: `"arn:aws:s3:::${var.unknown}/*"`,
+			Expected: "arn:aws:s3:::${}/*",
+			Literals: []string{"arn:aws:s3:::", "/*"},
+		},
+		{
+			Name:     "references that can't be resolved are placeholders",
+			Template: `"${aws_s3_bucket.logs.arn}/${each.key},arn:aws:sqs:eu-west-1::queue"`,
+			Expected: "${}/${},arn:aws:sqs:eu-west-1::queue",
+			Literals: []string{"/", ",arn:aws:sqs:eu-west-1::queue"},
+		},
+		{
+			Name:     "directives that need variables are placeholders",
+			Template: `"a%{ if var.known == "aws" }b%{ endif }c%{ for s in ["d"] }${s}%{ endfor }"`,
+			Expected: "a${}cd",
+			Literals: []string{"a", "c"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			content := `
+variable "unknown" {}
+variable "known" {
+  default = "aws"
+}
+
+locals {
+  policy = ` + test.Template + `
+}`
+			runner := helper.TestRunner(t, map[string]string{"main.tf": content})
+
+			files, err := runner.GetFiles()
+			if err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+			blocks := files["main.tf"].Body.(*hclsyntax.Body).Blocks
+			expr := blocks[len(blocks)-1].Body.Attributes["policy"].Expr.(*hclsyntax.TemplateExpr)
+
+			got, literals := renderTemplate(runner, files, expr)
+			if got != test.Expected {
+				t.Errorf("Expected %q, got %q", test.Expected, got)
+			}
+
+			var values []string
+			for _, literal := range literals {
+				values = append(values, literal.Value)
+				if got[literal.Start:literal.Start+len(literal.Value)] != literal.Value {
+					t.Errorf("Literal %q is not at %d", literal.Value, literal.Start)
+				}
+			}
+			if strings.Join(values, ",") != strings.Join(test.Literals, ",") {
+				t.Errorf("Expected literals %v, got %v", test.Literals, values)
+			}
+		})
+	}
+}